@@ -2,57 +2,96 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"sort"
 	"strconv"
-	"sync"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
 )
 
 // User defines the structure for a user.
 type User struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
+	ID    int    `json:"id"`
+	Name  string `json:"name" validate:"required,min=2,max=100,chars=name"`
+	Email string `json:"email,omitempty" validate:"max=255,email"`
 }
 
-// In-memory "database"
-var (
-	users  = make(map[int]User)
-	nextID = 1
-	mu     sync.Mutex // To safely handle concurrent requests
-)
+// maxBodyBytes caps request bodies well above any valid user.
+const maxBodyBytes = 1 << 20
+
+// Handler serves the user API from a UserStore.
+type Handler struct {
+	store UserStore
+}
+
+// NewHandler returns handlers backed by store.
+func NewHandler(store UserStore) *Handler {
+	return &Handler{store: store}
+}
+
+// Routes registers the user API on r, along with the middleware that
+// gives every response a request ID and every error a problem body. Call
+// it before adding other routes to r.
+func (h *Handler) Routes(r chi.Router) {
+	r.Use(middleware.RequestID, echoRequestID, recoverProblem)
+	r.NotFound(notFoundProblem)
+	r.MethodNotAllowed(methodNotAllowedProblem)
+
+	r.Get("/users", h.getAllUsersHandler)
+	r.Post("/users", h.createUserHandler)
+	r.Get("/users/{id}", h.getUserHandler)
+	r.Put("/users/{id}", h.updateUserHandler)
+	r.Patch("/users/{id}", h.patchUserHandler)
+	r.Delete("/users/{id}", h.deleteUserHandler)
+}
 
 // --- HANDLERS ---
 
 // getAllUsersHandler handles GET /users
-func getAllUsersHandler(w http.ResponseWriter, r *http.Request) {
-	mu.Lock()
-	defer mu.Unlock()
+func (h *Handler) getAllUsersHandler(w http.ResponseWriter, r *http.Request) {
+	q, err := parseListQuery(r.URL.Query())
+	if err != nil {
+		writeProblem(w, r, newProblem(http.StatusBadRequest, "invalid-query", "Invalid query parameter", err.Error()))
+		return
+	}
+
+	page, err := h.store.List(r.Context(), q)
+	if err != nil {
+		writeProblem(w, r, err)
+		return
+	}
 
-	var userList []User
-	for _, user := range users {
-		userList = append(userList, user)
+	resp := pageResponse{
+		Data:       page.Users,
+		Pagination: pageMetadata{Total: page.Total, Limit: q.Limit, Offset: q.Offset},
+	}
+	if page.Next != nil {
+		resp.Pagination.NextCursor = page.Next.Encode()
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(userList)
+	json.NewEncoder(w).Encode(resp)
 }
 
 // createUserHandler handles POST /users
-func createUserHandler(w http.ResponseWriter, r *http.Request) {
-	var user User
-	if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+func (h *Handler) createUserHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := decodeUser(w, r)
+	if !ok {
 		return
 	}
 
-	mu.Lock()
-	defer mu.Unlock()
-
-	user.ID = nextID
-	nextID++
-	users[user.ID] = user
+	user, err := h.store.Create(r.Context(), user)
+	if err != nil {
+		writeProblem(w, r, err)
+		return
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
@@ -60,19 +99,16 @@ func createUserHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 // getUserHandler handles GET /users/{id}
-func getUserHandler(w http.ResponseWriter, r *http.Request) {
-	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+func (h *Handler) getUserHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := userID(r)
 	if err != nil {
-		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		writeProblem(w, r, err)
 		return
 	}
 
-	mu.Lock()
-	defer mu.Unlock()
-
-	user, ok := users[id]
-	if !ok {
-		http.Error(w, "User not found", http.StatusNotFound)
+	user, err := h.store.Get(r.Context(), id)
+	if err != nil {
+		writeProblem(w, r, err)
 		return
 	}
 
@@ -81,51 +117,171 @@ func getUserHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 // updateUserHandler handles PUT /users/{id}
-func updateUserHandler(w http.ResponseWriter, r *http.Request) {
-	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+func (h *Handler) updateUserHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := userID(r)
 	if err != nil {
-		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		writeProblem(w, r, err)
+		return
+	}
+
+	updatedUser, ok := decodeUser(w, r)
+	if !ok {
 		return
 	}
 
-	var updatedUser User
-	if err := json.NewDecoder(r.Body).Decode(&updatedUser); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	updatedUser.ID = id
+	updatedUser, err = h.store.Update(r.Context(), updatedUser)
+	if err != nil {
+		writeProblem(w, r, err)
 		return
 	}
 
-	mu.Lock()
-	defer mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updatedUser)
+}
 
-	_, ok := users[id]
-	if !ok {
-		http.Error(w, "User not found", http.StatusNotFound)
+// patchUserHandler handles PATCH /users/{id}. The body is a JSON Merge
+// Patch or a JSON Patch, chosen by Content-Type. The patched user is
+// validated like a PUT body before it is stored. The read, patch and write
+// happen in one UserStore.Modify call, so a JSON Patch "test" operation
+// guards against concurrent changes.
+func (h *Handler) patchUserHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := userID(r)
+	if err != nil {
+		writeProblem(w, r, err)
 		return
 	}
 
-	updatedUser.ID = id
-	users[id] = updatedUser
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != mergePatchType && mediaType != jsonPatchType {
+		w.Header().Set("Accept-Patch", mergePatchType+", "+jsonPatchType)
+		writeProblem(w, r, newProblem(http.StatusUnsupportedMediaType, "unsupported-media-type", "Unsupported media type",
+			"PATCH bodies must be "+mergePatchType+" or "+jsonPatchType))
+		return
+	}
+
+	// Read the body first so the store is not held while the client sends it
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		writeProblem(w, r, err)
+		return
+	}
+
+	patched, err := h.store.Modify(r.Context(), id, func(user User) (User, error) {
+		return patchUser(user, mediaType, bytes.NewReader(body))
+	})
+	if err != nil {
+		writeProblem(w, r, err)
+		return
+	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(updatedUser)
+	json.NewEncoder(w).Encode(patched)
+}
+
+// patchUser applies the patch in body to user and returns the validated
+// result. The ID cannot be changed and unknown members are rejected.
+func patchUser(user User, mediaType string, body io.Reader) (User, error) {
+	var doc any
+	data, _ := json.Marshal(user)
+	json.Unmarshal(data, &doc)
+
+	if mediaType == mergePatchType {
+		var patch any
+		if err := json.NewDecoder(body).Decode(&patch); err != nil {
+			return User{}, err
+		}
+		doc = mergePatch(doc, patch)
+	} else {
+		var ops []patchOp
+		if err := json.NewDecoder(body).Decode(&ops); err != nil {
+			return User{}, fmt.Errorf("%w: body must be an array of operations", errInvalidPatch)
+		}
+		var err error
+		if doc, err = applyJSONPatch(doc, ops); err != nil {
+			return User{}, err
+		}
+	}
+
+	obj, ok := doc.(map[string]any)
+	if !ok {
+		return User{}, fmt.Errorf("%w: the patched user must be an object", errPatchConflict)
+	}
+	var errs ValidationErrors
+	switch id := obj["id"].(type) {
+	case float64:
+		if int(id) != user.ID {
+			errs = append(errs, FieldError{Field: "id", Code: "immutable", Message: "id cannot be changed"})
+		}
+	case nil:
+		errs = append(errs, FieldError{Field: "id", Code: "immutable", Message: "id cannot be changed"})
+	}
+
+	// A removed name decodes as empty and fails validation below
+	var patched User
+	data, _ = json.Marshal(obj)
+	if err := decodeFields(data, &patched); err != nil {
+		var fieldErrs ValidationErrors
+		if !errors.As(err, &fieldErrs) {
+			return User{}, err
+		}
+		errs = append(errs, fieldErrs...)
+	}
+	if len(errs) > 0 {
+		sort.Slice(errs, func(i, j int) bool { return errs[i].Field < errs[j].Field })
+		return User{}, errs
+	}
+	if errs := validate(patched); len(errs) > 0 {
+		return User{}, errs
+	}
+	return patched, nil
 }
 
 // deleteUserHandler handles DELETE /users/{id}
-func deleteUserHandler(w http.ResponseWriter, r *http.Request) {
-	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+func (h *Handler) deleteUserHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := userID(r)
 	if err != nil {
-		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		writeProblem(w, r, err)
 		return
 	}
 
-	mu.Lock()
-	defer mu.Unlock()
-
-	if _, ok := users[id]; !ok {
-		http.Error(w, "User not found", http.StatusNotFound)
+	if err := h.store.Delete(r.Context(), id); err != nil {
+		writeProblem(w, r, err)
 		return
 	}
 
-	delete(users, id)
 	w.WriteHeader(http.StatusNoContent)
-}
\ No newline at end of file
+}
+
+// decodeUser reads and validates the user in a POST or PUT body. Unknown
+// members are rejected, as they are in PATCH. It writes the error response
+// and returns false if the body is unusable.
+func decodeUser(w http.ResponseWriter, r *http.Request) (User, bool) {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		writeProblem(w, r, err)
+		return User{}, false
+	}
+
+	var user User
+	if err := decodeFields(data, &user); err != nil {
+		writeProblem(w, r, err)
+		return User{}, false
+	}
+
+	if errs := validate(user); len(errs) > 0 {
+		writeProblem(w, r, errs)
+		return User{}, false
+	}
+	return user, true
+}
+
+// userID reads the {id} URL parameter.
+func userID(r *http.Request) (int, error) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		return 0, newProblem(http.StatusBadRequest, "invalid-id", "Invalid user ID",
+			"user ID must be an integer")
+	}
+	return id, nil
+}
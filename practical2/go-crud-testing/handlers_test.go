@@ -3,6 +3,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -11,14 +12,23 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
-// A helper function to reset the state before each test
-func resetState() {
-	users = make(map[int]User)
-	nextID = 1
+// A helper function that gives each test its own store and router
+func newTestRouter(t *testing.T, seed ...User) (*MemoryUserStore, chi.Router) {
+	t.Helper()
+	store := NewMemoryUserStore()
+	for _, user := range seed {
+		if _, err := store.Create(context.Background(), user); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	router := chi.NewRouter()
+	NewHandler(store).Routes(router)
+	return store, router
 }
 
 func TestCreateUserHandler(t *testing.T) {
-	resetState()
+	_, router := newTestRouter(t)
 
 	// 1. Define the user we want to create
 	userPayload := `{"name": "John Doe"}`
@@ -30,9 +40,7 @@ func TestCreateUserHandler(t *testing.T) {
 	// 2. Create a ResponseRecorder to record the response
 	rr := httptest.NewRecorder()
 
-	// 3. Create a router and serve the request
-	router := chi.NewRouter()
-	router.Post("/users", createUserHandler)
+	// 3. Serve the request
 	router.ServeHTTP(rr, req)
 
 	// 4. Check the status code
@@ -55,11 +63,68 @@ func TestCreateUserHandler(t *testing.T) {
 	}
 }
 
-func TestGetUserHandler(t *testing.T) {
-	resetState()
+func TestGetAllUsersHandler(t *testing.T) {
+	_, router := newTestRouter(t, User{Name: "Carol"}, User{Name: "Alice"}, User{Name: "Bob"})
+
+	get := func(t *testing.T, url string) (int, pageResponse) {
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		var resp pageResponse
+		json.NewDecoder(rr.Body).Decode(&resp)
+		return rr.Code, resp
+	}
+
+	t.Run("First Page", func(t *testing.T) {
+		status, resp := get(t, "/users?sort=name&limit=2")
+		if status != http.StatusOK {
+			t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+		}
+		if len(resp.Data) != 2 || resp.Data[0].Name != "Alice" || resp.Data[1].Name != "Bob" {
+			t.Errorf("handler returned unexpected users: got %v", resp.Data)
+		}
+		if resp.Pagination.Total != 3 || resp.Pagination.NextCursor == "" {
+			t.Errorf("handler returned unexpected pagination: got %+v", resp.Pagination)
+		}
+
+		_, next := get(t, "/users?sort=name&limit=2&cursor="+resp.Pagination.NextCursor)
+		if len(next.Data) != 1 || next.Data[0].Name != "Carol" || next.Pagination.NextCursor != "" {
+			t.Errorf("handler returned unexpected second page: got %+v", next)
+		}
+	})
+
+	t.Run("Empty Result", func(t *testing.T) {
+		_, resp := get(t, "/users?name_prefix=zed")
+		if resp.Data == nil || len(resp.Data) != 0 || resp.Pagination.Total != 0 {
+			t.Errorf("handler returned unexpected body: got %+v", resp)
+		}
+	})
+
+	t.Run("Bad Query", func(t *testing.T) {
+		for _, url := range []string{
+			"/users?limit=0",
+			"/users?limit=1000",
+			"/users?offset=-1",
+			"/users?sort=email",
+			"/users?order=sideways",
+			"/users?cursor=!!!",
+			"/users?cursor=" + (Cursor{Sort: SortByID, ID: 1}).Encode() + "&sort=name",
+			"/users?cursor=" + (Cursor{Sort: SortByID, ID: 1}).Encode() + "&offset=2",
+		} {
+			if status, _ := get(t, url); status != http.StatusBadRequest {
+				t.Errorf("%s: handler returned wrong status code: got %v want %v", url, status, http.StatusBadRequest)
+			}
+		}
+	})
+}
 
+func TestGetUserHandler(t *testing.T) {
 	// First, create a user to fetch
-	users[1] = User{ID: 1, Name: "Jane Doe"}
+	_, router := newTestRouter(t, User{Name: "Jane Doe"})
 
 	// Test case 1: User found
 	t.Run("User Found", func(t *testing.T) {
@@ -69,8 +134,6 @@ func TestGetUserHandler(t *testing.T) {
 		}
 
 		rr := httptest.NewRecorder()
-		router := chi.NewRouter()
-		router.Get("/users/{id}", getUserHandler)
 		router.ServeHTTP(rr, req)
 
 		if status := rr.Code; status != http.StatusOK {
@@ -92,8 +155,6 @@ func TestGetUserHandler(t *testing.T) {
 		}
 
 		rr := httptest.NewRecorder()
-		router := chi.NewRouter()
-		router.Get("/users/{id}", getUserHandler)
 		router.ServeHTTP(rr, req)
 
 		if status := rr.Code; status != http.StatusNotFound {
@@ -102,11 +163,38 @@ func TestGetUserHandler(t *testing.T) {
 	})
 }
 
-// You would continue this pattern for updateUserHandler and deleteUserHandler...
+func TestUpdateUserHandler(t *testing.T) {
+	store, router := newTestRouter(t, User{Name: "Old Name"})
+
+	req, err := http.NewRequest("PUT", "/users/1", bytes.NewBufferString(`{"name": "New Name"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	user, _ := store.Get(context.Background(), 1)
+	if user.Name != "New Name" {
+		t.Errorf("user was not updated in the store: got %v want %v", user.Name, "New Name")
+	}
+
+	// Updating a missing user
+	req, _ = http.NewRequest("PUT", "/users/99", bytes.NewBufferString(`{"name": "Nobody"}`))
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusNotFound {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNotFound)
+	}
+}
 
 func TestDeleteUserHandler(t *testing.T) {
-	resetState()
-	users[1] = User{ID: 1, Name: "To Be Deleted"}
+	store, router := newTestRouter(t, User{Name: "To Be Deleted"})
 
 	req, err := http.NewRequest("DELETE", "/users/1", nil)
 	if err != nil {
@@ -114,8 +202,6 @@ func TestDeleteUserHandler(t *testing.T) {
 	}
 
 	rr := httptest.NewRecorder()
-	router := chi.NewRouter()
-	router.Delete("/users/{id}", deleteUserHandler)
 	router.ServeHTTP(rr, req)
 
 	if status := rr.Code; status != http.StatusNoContent {
@@ -123,7 +209,7 @@ func TestDeleteUserHandler(t *testing.T) {
 	}
 
 	// Verify the user was actually deleted
-	if _, ok := users[1]; ok {
-		t.Error("user was not deleted from the map")
+	if _, err := store.Get(context.Background(), 1); err != ErrUserNotFound {
+		t.Error("user was not deleted from the store")
 	}
-}
\ No newline at end of file
+}
@@ -2,26 +2,48 @@
 package main
 
 import (
+	"database/sql"
 	"log"
 	"net/http"
+	"os"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	_ "github.com/lib/pq"
 )
 
 func main() {
+	store, err := newStore()
+	if err != nil {
+		log.Fatalf("Could not open user store: %s\n", err)
+	}
+
 	r := chi.NewRouter()
 	r.Use(middleware.Logger)
 
 	// Setup routes
-	r.Get("/users", getAllUsersHandler)
-	r.Post("/users", createUserHandler)
-	r.Get("/users/{id}", getUserHandler)
-	r.Put("/users/{id}", updateUserHandler)
-	r.Delete("/users/{id}", deleteUserHandler)
+	NewHandler(store).Routes(r)
 
 	log.Println("Server starting on :3000")
 	if err := http.ListenAndServe(":3000", r); err != nil {
 		log.Fatalf("Could not start server: %s\n", err)
 	}
-}
\ No newline at end of file
+}
+
+// newStore uses Postgres when DATABASE_URL is set and memory otherwise.
+func newStore() (UserStore, error) {
+	dsn := os.Getenv("DATABASE_URL")
+	if dsn == "" {
+		return NewMemoryUserStore(), nil
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(PostgresSchema); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return NewPostgresUserStore(db), nil
+}
@@ -0,0 +1,169 @@
+// pagination.go
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// SortField is a column users can be ordered by. Ties are always broken
+// by ID so the order is stable across pages.
+type SortField string
+
+const (
+	SortByID   SortField = "id"
+	SortByName SortField = "name"
+)
+
+// ListQuery selects a page of users. Use either Offset or Cursor, not both.
+type ListQuery struct {
+	// Limit is the page size. Zero means no limit.
+	Limit  int
+	Offset int
+	// Cursor continues after the last user of a previous page.
+	Cursor *Cursor
+	Sort   SortField
+	Desc   bool
+	// NamePrefix and NameContains filter by name, ignoring case.
+	NamePrefix   string
+	NameContains string
+}
+
+// UserPage is one page of a user listing.
+type UserPage struct {
+	Users []User
+	// Total counts every user matching the filters, across all pages.
+	Total int
+	// Next continues the listing, or is nil on the last page.
+	Next *Cursor
+}
+
+// Cursor marks the last user of a page, under a particular sort order.
+type Cursor struct {
+	Sort SortField `json:"s"`
+	Desc bool      `json:"d,omitempty"`
+	ID   int       `json:"i"`
+	Name string    `json:"n,omitempty"`
+}
+
+// cursorAfter returns the cursor that continues after user.
+func cursorAfter(q ListQuery, user User) *Cursor {
+	c := &Cursor{Sort: q.Sort, Desc: q.Desc, ID: user.ID}
+	if q.Sort == SortByName {
+		c.Name = user.Name
+	}
+	return c
+}
+
+// Encode returns the opaque token sent to clients.
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a token produced by Cursor.Encode.
+func DecodeCursor(token string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	return &c, nil
+}
+
+// parseListQuery reads limit, offset, cursor, sort, order, name_prefix
+// and name_contains from the query string.
+func parseListQuery(values url.Values) (ListQuery, error) {
+	q := ListQuery{
+		Limit:        defaultPageSize,
+		Sort:         SortByID,
+		NamePrefix:   values.Get("name_prefix"),
+		NameContains: values.Get("name_contains"),
+	}
+
+	if v := values.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxPageSize {
+			return ListQuery{}, fmt.Errorf("limit must be between 1 and %d", maxPageSize)
+		}
+		q.Limit = limit
+	}
+	if v := values.Get("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			return ListQuery{}, errors.New("offset must be a non-negative integer")
+		}
+		q.Offset = offset
+	}
+
+	switch sort := SortField(values.Get("sort")); sort {
+	case "":
+	case SortByID, SortByName:
+		q.Sort = sort
+	default:
+		return ListQuery{}, errors.New("sort must be id or name")
+	}
+	switch strings.ToLower(values.Get("order")) {
+	case "", "asc":
+	case "desc":
+		q.Desc = true
+	default:
+		return ListQuery{}, errors.New("order must be asc or desc")
+	}
+
+	if token := values.Get("cursor"); token != "" {
+		if values.Has("offset") {
+			return ListQuery{}, errors.New("use either cursor or offset, not both")
+		}
+		c, err := DecodeCursor(token)
+		if err != nil {
+			return ListQuery{}, err
+		}
+		if c.Sort != q.Sort || c.Desc != q.Desc {
+			return ListQuery{}, errors.New("cursor does not match the sort order")
+		}
+		q.Cursor = c
+	}
+	return q, nil
+}
+
+// less orders users for q, breaking ties by ID.
+func (q ListQuery) less(a, b User) bool {
+	if q.Sort == SortByName && a.Name != b.Name {
+		return (a.Name < b.Name) != q.Desc
+	}
+	return (a.ID < b.ID) != q.Desc
+}
+
+// matches reports whether user passes the name filters.
+func (q ListQuery) matches(user User) bool {
+	name := strings.ToLower(user.Name)
+	return strings.HasPrefix(name, strings.ToLower(q.NamePrefix)) &&
+		strings.Contains(name, strings.ToLower(q.NameContains))
+}
+
+// pageResponse is the body of GET /users.
+type pageResponse struct {
+	Data       []User       `json:"data"`
+	Pagination pageMetadata `json:"pagination"`
+}
+
+type pageMetadata struct {
+	Total      int    `json:"total"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,242 @@
+// patch.go
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Media types accepted by PATCH /users/{id}.
+const (
+	mergePatchType = "application/merge-patch+json"
+	jsonPatchType  = "application/json-patch+json"
+)
+
+var (
+	// errInvalidPatch means the patch document itself is malformed.
+	errInvalidPatch = errors.New("invalid patch")
+	// errPatchConflict means a well-formed patch does not fit the document,
+	// such as a path that does not exist.
+	errPatchConflict = errors.New("patch cannot be applied")
+	// errPatchTestFailed means a JSON Patch "test" operation did not match.
+	errPatchTestFailed = errors.New("patch test failed")
+)
+
+// mergePatch applies an RFC 7396 merge patch to target: objects are merged
+// recursively, null deletes a member and anything else replaces it.
+func mergePatch(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = map[string]any{}
+	}
+	for key, value := range p {
+		if value == nil {
+			delete(t, key)
+			continue
+		}
+		t[key] = mergePatch(t[key], value)
+	}
+	return t
+}
+
+// patchOp is one RFC 6902 operation. Value is nil when the member is
+// absent, which is different from a JSON null.
+type patchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// applyJSONPatch applies the add, replace, remove and test operations of
+// RFC 6902 to doc. The operations are applied in order and the first
+// failure aborts the patch.
+func applyJSONPatch(doc any, ops []patchOp) (any, error) {
+	for i, op := range ops {
+		var err error
+		if doc, err = applyOp(doc, op); err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+	return doc, nil
+}
+
+func applyOp(doc any, op patchOp) (any, error) {
+	tokens, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var value any
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, fmt.Errorf("%w: %s requires a value", errInvalidPatch, op.Op)
+		}
+		if err := json.Unmarshal(op.Value, &value); err != nil {
+			return nil, fmt.Errorf("%w: value is not valid JSON", errInvalidPatch)
+		}
+	case "remove":
+	case "":
+		return nil, fmt.Errorf("%w: op is required", errInvalidPatch)
+	default:
+		return nil, fmt.Errorf("%w: unsupported op %q", errInvalidPatch, op.Op)
+	}
+
+	if op.Op == "test" {
+		current, err := lookup(doc, tokens)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(current, value) {
+			return nil, fmt.Errorf("%w: %s does not match", errPatchTestFailed, op.Path)
+		}
+		return doc, nil
+	}
+
+	if len(tokens) == 0 {
+		if op.Op == "remove" {
+			return nil, fmt.Errorf("%w: cannot remove the whole document", errPatchConflict)
+		}
+		return value, nil
+	}
+	return patchAt(doc, tokens, func(container any, key string) (any, error) {
+		return modify(container, key, op.Op, value)
+	})
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into unescaped tokens.
+func parsePointer(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("%w: path %q must start with /", errInvalidPatch, path)
+	}
+	tokens := strings.Split(path[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(t)
+	}
+	return tokens, nil
+}
+
+// patchAt walks to the container holding the last token, lets fn change
+// it and returns the updated document.
+func patchAt(node any, tokens []string, fn func(container any, key string) (any, error)) (any, error) {
+	if len(tokens) == 1 {
+		return fn(node, tokens[0])
+	}
+
+	switch n := node.(type) {
+	case map[string]any:
+		child, ok := n[tokens[0]]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s does not exist", errPatchConflict, tokens[0])
+		}
+		updated, err := patchAt(child, tokens[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		n[tokens[0]] = updated
+		return n, nil
+	case []any:
+		i, err := arrayIndex(tokens[0], len(n)-1)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := patchAt(n[i], tokens[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		n[i] = updated
+		return n, nil
+	default:
+		return nil, fmt.Errorf("%w: %s is not an object or array", errPatchConflict, tokens[0])
+	}
+}
+
+// modify applies add, replace or remove to one member of container.
+func modify(container any, key, op string, value any) (any, error) {
+	switch c := container.(type) {
+	case map[string]any:
+		if _, exists := c[key]; !exists && op != "add" {
+			return nil, fmt.Errorf("%w: %s does not exist", errPatchConflict, key)
+		}
+		if op == "remove" {
+			delete(c, key)
+		} else {
+			c[key] = value
+		}
+		return c, nil
+	case []any:
+		switch op {
+		case "add":
+			i := len(c)
+			if key != "-" {
+				var err error
+				if i, err = arrayIndex(key, len(c)); err != nil {
+					return nil, err
+				}
+			}
+			return append(c[:i], append([]any{value}, c[i:]...)...), nil
+		case "replace":
+			i, err := arrayIndex(key, len(c)-1)
+			if err != nil {
+				return nil, err
+			}
+			c[i] = value
+			return c, nil
+		default:
+			i, err := arrayIndex(key, len(c)-1)
+			if err != nil {
+				return nil, err
+			}
+			return append(c[:i], c[i+1:]...), nil
+		}
+	default:
+		return nil, fmt.Errorf("%w: cannot %s %s on a scalar", errPatchConflict, op, key)
+	}
+}
+
+// lookup returns the value the pointer tokens refer to.
+func lookup(node any, tokens []string) (any, error) {
+	for _, t := range tokens {
+		switch n := node.(type) {
+		case map[string]any:
+			child, ok := n[t]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s does not exist", errPatchConflict, t)
+			}
+			node = child
+		case []any:
+			i, err := arrayIndex(t, len(n)-1)
+			if err != nil {
+				return nil, err
+			}
+			node = n[i]
+		default:
+			return nil, fmt.Errorf("%w: %s does not exist", errPatchConflict, t)
+		}
+	}
+	return node, nil
+}
+
+// arrayIndex parses an array index token, which must be a plain decimal
+// no greater than max.
+func arrayIndex(token string, max int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || (len(token) > 1 && token[0] == '0') || strings.HasPrefix(token, "+") {
+		return 0, fmt.Errorf("%w: invalid array index %q", errInvalidPatch, token)
+	}
+	if i > max {
+		return 0, fmt.Errorf("%w: array index %d out of range", errPatchConflict, i)
+	}
+	return i, nil
+}
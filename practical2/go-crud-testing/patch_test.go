@@ -0,0 +1,156 @@
+// patch_test.go
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func decodeJSON(t *testing.T, s string) any {
+	t.Helper()
+	var v any
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestMergePatch(t *testing.T) {
+	// Examples from RFC 7396 appendix A
+	tests := []struct {
+		target, patch, want string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.patch, func(t *testing.T) {
+			got := mergePatch(decodeJSON(t, tt.target), decodeJSON(t, tt.patch))
+			if want := decodeJSON(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("mergePatch returned %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		patch   string
+		want    string
+		wantErr error
+	}{
+		{"Add Member", `{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"foo":"bar","baz":"qux"}`, nil},
+		{"Add Array Element", `{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`, nil},
+		{"Append", `{"foo":[1]}`, `[{"op":"add","path":"/foo/-","value":2}]`, `{"foo":[1,2]}`, nil},
+		{"Remove Array Element", `{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`, nil},
+		{"Replace", `{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`, nil},
+		{"Escaped Pointer", `{"a/b":1,"m~n":2}`, `[{"op":"replace","path":"/a~1b","value":3},{"op":"remove","path":"/m~0n"}]`, `{"a/b":3}`, nil},
+		{"Add Null", `{}`, `[{"op":"add","path":"/a","value":null}]`, `{"a":null}`, nil},
+		{"Test Then Replace", `{"a":1}`, `[{"op":"test","path":"/a","value":1},{"op":"replace","path":"/a","value":2}]`, `{"a":2}`, nil},
+		{"Test Fails", `{"a":1}`, `[{"op":"test","path":"/a","value":"1"}]`, ``, errPatchTestFailed},
+		{"Replace Missing", `{}`, `[{"op":"replace","path":"/a","value":1}]`, ``, errPatchConflict},
+		{"Remove Missing", `{}`, `[{"op":"remove","path":"/a"}]`, ``, errPatchConflict},
+		{"Index Out Of Range", `{"a":[]}`, `[{"op":"add","path":"/a/1","value":1}]`, ``, errPatchConflict},
+		{"Leading Zero Index", `{"a":[1,2]}`, `[{"op":"remove","path":"/a/01"}]`, ``, errInvalidPatch},
+		{"Missing Value", `{}`, `[{"op":"add","path":"/a"}]`, ``, errInvalidPatch},
+		{"Unsupported Op", `{}`, `[{"op":"move","from":"/a","path":"/b"}]`, ``, errInvalidPatch},
+		{"Bad Pointer", `{}`, `[{"op":"remove","path":"a"}]`, ``, errInvalidPatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ops []patchOp
+			if err := json.Unmarshal([]byte(tt.patch), &ops); err != nil {
+				t.Fatal(err)
+			}
+			got, err := applyJSONPatch(decodeJSON(t, tt.doc), ops)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("applyJSONPatch returned error %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("applyJSONPatch returned error %v", err)
+			}
+			if want := decodeJSON(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("applyJSONPatch returned %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestPatchUserHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantStatus  int
+		wantName    string
+	}{
+		{"Merge Patch", mergePatchType, `{"name": "Jane Smith"}`, http.StatusOK, "Jane Smith"},
+		{"Merge Patch Keeps Omitted Fields", mergePatchType, `{}`, http.StatusOK, "Jane Doe"},
+		{"Merge Patch Removing Name", mergePatchType, `{"name": null}`, http.StatusUnprocessableEntity, "Jane Doe"},
+		{"Merge Patch Unknown Field", mergePatchType, `{"phone": "555-0100"}`, http.StatusUnprocessableEntity, "Jane Doe"},
+		{"Merge Patch Invalid Email", mergePatchType, `{"email": "not an email"}`, http.StatusUnprocessableEntity, "Jane Doe"},
+		{"Merge Patch Changing ID", mergePatchType, `{"id": 2}`, http.StatusUnprocessableEntity, "Jane Doe"},
+		{"Merge Patch Wrong Type", mergePatchType, `{"name": 123}`, http.StatusUnprocessableEntity, "Jane Doe"},
+		{"JSON Patch", jsonPatchType + "; charset=utf-8", `[{"op": "test", "path": "/name", "value": "Jane Doe"}, {"op": "replace", "path": "/name", "value": "Janet Doe"}]`, http.StatusOK, "Janet Doe"},
+		{"JSON Patch Failed Test", jsonPatchType, `[{"op": "test", "path": "/name", "value": "Someone Else"}, {"op": "replace", "path": "/name", "value": "Janet Doe"}]`, http.StatusConflict, "Jane Doe"},
+		{"JSON Patch Invalid Result", jsonPatchType, `[{"op": "replace", "path": "/name", "value": "J4ne"}]`, http.StatusUnprocessableEntity, "Jane Doe"},
+		{"JSON Patch Not An Array", jsonPatchType, `{"op": "replace"}`, http.StatusBadRequest, "Jane Doe"},
+		{"Plain JSON", "application/json", `{"name": "Jane Smith"}`, http.StatusUnsupportedMediaType, "Jane Doe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, router := newTestRouter(t, User{Name: "Jane Doe"})
+
+			req, err := http.NewRequest("PATCH", "/users/1", bytes.NewBufferString(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", tt.contentType)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tt.wantStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v: %s", status, tt.wantStatus, rr.Body)
+			}
+			user, _ := store.Get(context.Background(), 1)
+			if user.Name != tt.wantName {
+				t.Errorf("stored user has name %v, want %v", user.Name, tt.wantName)
+			}
+			if tt.wantStatus == http.StatusUnsupportedMediaType && rr.Header().Get("Accept-Patch") == "" {
+				t.Error("handler did not advertise Accept-Patch")
+			}
+		})
+	}
+
+	t.Run("User Not Found", func(t *testing.T) {
+		_, router := newTestRouter(t)
+		req, _ := http.NewRequest("PATCH", "/users/99", bytes.NewBufferString(`{"name": "Nobody"}`))
+		req.Header.Set("Content-Type", mergePatchType)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		if status := rr.Code; status != http.StatusNotFound {
+			t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNotFound)
+		}
+	})
+}
@@ -0,0 +1,233 @@
+// postgres_store.go
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// PostgresSchema creates the users table of practical5/testcontainers-demo
+// (migrations/init.sql), so the API can share that database.
+const PostgresSchema = `
+CREATE TABLE IF NOT EXISTS users (
+    id SERIAL PRIMARY KEY,
+    email VARCHAR(255) UNIQUE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+)`
+
+// PostgresUserStore stores users in the testcontainers-demo users table.
+// Its queries follow that project's UserRepository, which is not imported
+// because it lives in its own module and also depends on Redis.
+//
+// The table requires every user to have an email, so users without one
+// are rejected with a validation error rather than a database error.
+type PostgresUserStore struct {
+	db *sql.DB
+}
+
+// NewPostgresUserStore returns a store using db. The caller registers the
+// driver and runs PostgresSchema.
+func NewPostgresUserStore(db *sql.DB) *PostgresUserStore {
+	return &PostgresUserStore{db: db}
+}
+
+// List pages through users with keyset pagination when q has a cursor.
+// Names are compared with the C collation so the order matches
+// MemoryUserStore.
+func (s *PostgresUserStore) List(ctx context.Context, q ListQuery) (UserPage, error) {
+	var where []string
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if q.NamePrefix != "" {
+		where = append(where, "name ILIKE "+arg(escapeLike(q.NamePrefix)+"%")+` ESCAPE '\'`)
+	}
+	if q.NameContains != "" {
+		where = append(where, "name ILIKE "+arg("%"+escapeLike(q.NameContains)+"%")+` ESCAPE '\'`)
+	}
+
+	var page UserPage
+	countQuery := "SELECT COUNT(*) FROM users" + whereClause(where)
+	if err := s.db.QueryRowContext(ctx, countQuery, args...).Scan(&page.Total); err != nil {
+		return UserPage{}, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	cmp, dir := ">", "ASC"
+	if q.Desc {
+		cmp, dir = "<", "DESC"
+	}
+	order := "id " + dir
+	if q.Sort == SortByName {
+		order = `name COLLATE "C" ` + dir + ", id " + dir
+	}
+	if c := q.Cursor; c != nil {
+		if q.Sort == SortByName {
+			where = append(where, `(name COLLATE "C", id) `+cmp+" ("+arg(c.Name)+", "+arg(c.ID)+")")
+		} else {
+			where = append(where, "id "+cmp+" "+arg(c.ID))
+		}
+	}
+
+	query := "SELECT id, name, email FROM users" + whereClause(where) + " ORDER BY " + order
+	if q.Limit > 0 {
+		// Fetch one extra row to learn whether there is a next page
+		query += " LIMIT " + arg(q.Limit+1)
+	}
+	if q.Offset > 0 {
+		query += " OFFSET " + arg(q.Offset)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return UserPage{}, fmt.Errorf("failed to list users: %w", err)
+	}
+	defer rows.Close()
+
+	page.Users = []User{}
+	for rows.Next() {
+		var user User
+		if err := rows.Scan(&user.ID, &user.Name, &user.Email); err != nil {
+			return UserPage{}, fmt.Errorf("failed to scan user: %w", err)
+		}
+		page.Users = append(page.Users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return UserPage{}, fmt.Errorf("error iterating users: %w", err)
+	}
+
+	if q.Limit > 0 && len(page.Users) > q.Limit {
+		page.Users = page.Users[:q.Limit]
+		page.Next = cursorAfter(q, page.Users[q.Limit-1])
+	}
+	return page, nil
+}
+
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// escapeLike stops user input from acting as LIKE wildcards.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+func (s *PostgresUserStore) Get(ctx context.Context, id int) (User, error) {
+	var user User
+	err := s.db.QueryRowContext(ctx, "SELECT id, name, email FROM users WHERE id = $1", id).Scan(&user.ID, &user.Name, &user.Email)
+	if errors.Is(err, sql.ErrNoRows) {
+		return User{}, ErrUserNotFound
+	}
+	if err != nil {
+		return User{}, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
+
+func (s *PostgresUserStore) Create(ctx context.Context, user User) (User, error) {
+	if err := requireEmail(user); err != nil {
+		return User{}, err
+	}
+	err := s.db.QueryRowContext(ctx, "INSERT INTO users (email, name) VALUES ($1, $2) RETURNING id", user.Email, user.Name).Scan(&user.ID)
+	if err != nil {
+		return User{}, writeError("create", err)
+	}
+	return user, nil
+}
+
+func (s *PostgresUserStore) Update(ctx context.Context, user User) (User, error) {
+	if err := requireEmail(user); err != nil {
+		return User{}, err
+	}
+	result, err := s.db.ExecContext(ctx, "UPDATE users SET email = $1, name = $2 WHERE id = $3", user.Email, user.Name, user.ID)
+	if err != nil {
+		return User{}, writeError("update", err)
+	}
+	if err := requireRow(result); err != nil {
+		return User{}, err
+	}
+	return user, nil
+}
+
+// Modify locks the row with SELECT ... FOR UPDATE so concurrent writers
+// wait for the transaction to finish.
+func (s *PostgresUserStore) Modify(ctx context.Context, id int, fn func(User) (User, error)) (User, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return User{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var user User
+	err = tx.QueryRowContext(ctx, "SELECT id, name, email FROM users WHERE id = $1 FOR UPDATE", id).Scan(&user.ID, &user.Name, &user.Email)
+	if errors.Is(err, sql.ErrNoRows) {
+		return User{}, ErrUserNotFound
+	}
+	if err != nil {
+		return User{}, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	user, err = fn(user)
+	if err != nil {
+		return User{}, err
+	}
+	user.ID = id
+	if err := requireEmail(user); err != nil {
+		return User{}, err
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE users SET email = $1, name = $2 WHERE id = $3", user.Email, user.Name, id); err != nil {
+		return User{}, writeError("update", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return User{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return user, nil
+}
+
+func (s *PostgresUserStore) Delete(ctx context.Context, id int) error {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM users WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	return requireRow(result)
+}
+
+// requireEmail reports a missing email the way validate would, since the
+// table cannot store a user without one.
+func requireEmail(user User) error {
+	if user.Email == "" {
+		return ValidationErrors{{Field: "email", Code: "required", Message: "email is required"}}
+	}
+	return nil
+}
+
+// writeError turns a unique violation on email into ErrEmailTaken.
+func writeError(op string, err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return ErrEmailTaken
+	}
+	return fmt.Errorf("failed to %s user: %w", op, err)
+}
+
+// requireRow turns an UPDATE or DELETE that matched nothing into ErrUserNotFound.
+func requireRow(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// postgres_store_test.go
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// TestPostgresUserStore starts the same Postgres container as the
+// practical5 testcontainers demo and runs the shared store tests against
+// it. It is skipped when no Docker daemon is reachable.
+func TestPostgresUserStore(t *testing.T) {
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+	ctx := context.Background()
+
+	container, err := postgres.Run(ctx, "postgres:15-alpine",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(30*time.Second)),
+	)
+	testcontainers.CleanupContainer(t, container)
+	if err != nil {
+		t.Fatalf("failed to start PostgreSQL container: %v", err)
+	}
+
+	dsn, err := container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	reset := func() {
+		if _, err := db.Exec("DROP TABLE IF EXISTS users"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := db.Exec(PostgresSchema); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reset()
+	testUserStore(t, NewPostgresUserStore(db))
+	reset()
+	testUserStoreModify(t, NewPostgresUserStore(db))
+	reset()
+	testUserStorePaging(t, NewPostgresUserStore(db))
+
+	t.Run("Email Required", func(t *testing.T) {
+		reset()
+		_, err := NewPostgresUserStore(db).Create(ctx, User{Name: "No Email"})
+		var verrs ValidationErrors
+		if !errors.As(err, &verrs) {
+			t.Fatalf("Create without email returned %v, want ValidationErrors", err)
+		}
+	})
+}
@@ -0,0 +1,130 @@
+// problems.go
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// problemTypeBase prefixes every problem type. Types are relative URIs, so
+// they resolve against whatever host serves the API.
+const problemTypeBase = "/problems/"
+
+// Problem is an RFC 7807 problem details object. It is also an error, so
+// handlers can build one where they detect the failure and pass it to
+// writeProblem like any other error.
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+	// Errors lists the failing fields of a validation problem.
+	Errors ValidationErrors `json:"errors,omitempty"`
+}
+
+func (p *Problem) Error() string { return p.Detail }
+
+// newProblem builds a problem of the given type, such as "invalid-id".
+func newProblem(status int, typ, title, detail string) *Problem {
+	return &Problem{Type: problemTypeBase + typ, Title: title, Status: status, Detail: detail}
+}
+
+// problemFor maps any error a handler can meet onto a problem. Errors it
+// does not recognise become a 500 whose detail hides the cause.
+func problemFor(err error) *Problem {
+	var (
+		problem    *Problem
+		validation ValidationErrors
+		tooLarge   *http.MaxBytesError
+		syntax     *json.SyntaxError
+	)
+
+	switch {
+	case errors.As(err, &problem):
+		p := *problem
+		return &p
+	case errors.As(err, &validation):
+		p := newProblem(http.StatusUnprocessableEntity, "validation-error", "Validation failed",
+			"one or more fields are invalid")
+		p.Errors = validation
+		return p
+	case errors.Is(err, errInvalidPatch):
+		return newProblem(http.StatusBadRequest, "malformed-patch", "Malformed patch document", err.Error())
+	case errors.Is(err, errPatchTestFailed):
+		return newProblem(http.StatusConflict, "patch-test-failed", "Patch test failed", err.Error())
+	case errors.Is(err, errPatchConflict):
+		return newProblem(http.StatusUnprocessableEntity, "patch-conflict", "Patch cannot be applied", err.Error())
+	case errors.Is(err, ErrEmailTaken):
+		return newProblem(http.StatusConflict, "email-taken", "Email already in use", err.Error())
+	case errors.Is(err, ErrUserNotFound):
+		return newProblem(http.StatusNotFound, "user-not-found", "User not found", err.Error())
+	case errors.As(err, &tooLarge):
+		return newProblem(http.StatusRequestEntityTooLarge, "body-too-large", "Request body too large",
+			fmt.Sprintf("request bodies are limited to %d bytes", tooLarge.Limit))
+	case errors.As(err, &syntax), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return newProblem(http.StatusBadRequest, "malformed-body", "Malformed request body",
+			"request body must be a JSON object")
+	default:
+		return newProblem(http.StatusInternalServerError, "internal-error", "Internal server error",
+			"an unexpected error occurred")
+	}
+}
+
+// writeProblem is the single place error responses are written. It fills
+// in the request path and ID and logs failures the client did not cause.
+func writeProblem(w http.ResponseWriter, r *http.Request, err error) {
+	p := problemFor(err)
+	p.Instance = r.URL.Path
+	p.RequestID = middleware.GetReqID(r.Context())
+	if p.Status >= http.StatusInternalServerError {
+		log.Printf("request %s: %s %s: %v", p.RequestID, r.Method, r.URL.Path, err)
+	}
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
+
+// echoRequestID returns the request ID to the client so it can be quoted
+// in bug reports. It must run after middleware.RequestID.
+func echoRequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id := middleware.GetReqID(r.Context()); id != "" {
+			w.Header().Set(middleware.RequestIDHeader, id)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// recoverProblem turns a panic in a handler into a 500 problem.
+func recoverProblem(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				if rec == http.ErrAbortHandler {
+					panic(rec)
+				}
+				writeProblem(w, r, fmt.Errorf("panic: %v", rec))
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+func notFoundProblem(w http.ResponseWriter, r *http.Request) {
+	writeProblem(w, r, newProblem(http.StatusNotFound, "not-found", "Not found",
+		"no route matches "+r.URL.Path))
+}
+
+func methodNotAllowedProblem(w http.ResponseWriter, r *http.Request) {
+	writeProblem(w, r, newProblem(http.StatusMethodNotAllowed, "method-not-allowed", "Method not allowed",
+		r.Method+" is not supported for "+r.URL.Path))
+}
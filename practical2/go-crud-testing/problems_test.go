@@ -0,0 +1,135 @@
+// problems_test.go
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// brokenStore fails every call, as a store does when its database is down.
+type brokenStore struct{}
+
+var errDatabaseDown = errors.New("dial tcp 10.0.0.5:5432: connection refused")
+
+func (brokenStore) List(ctx context.Context, q ListQuery) (UserPage, error) {
+	return UserPage{}, errDatabaseDown
+}
+func (brokenStore) Get(ctx context.Context, id int) (User, error)    { return User{}, errDatabaseDown }
+func (brokenStore) Create(ctx context.Context, u User) (User, error) { return User{}, errDatabaseDown }
+func (brokenStore) Update(ctx context.Context, u User) (User, error) { return User{}, errDatabaseDown }
+func (brokenStore) Delete(ctx context.Context, id int) error         { return errDatabaseDown }
+func (brokenStore) Modify(ctx context.Context, id int, fn func(User) (User, error)) (User, error) {
+	return User{}, errDatabaseDown
+}
+
+func TestProblemResponses(t *testing.T) {
+	_, router := newTestRouter(t, User{Name: "Jane Doe"})
+
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		body       string
+		wantStatus int
+		wantType   string
+	}{
+		{"User Not Found", "GET", "/users/99", "", http.StatusNotFound, "/problems/user-not-found"},
+		{"Invalid ID", "DELETE", "/users/abc", "", http.StatusBadRequest, "/problems/invalid-id"},
+		{"Invalid Query", "GET", "/users?limit=0", "", http.StatusBadRequest, "/problems/invalid-query"},
+		{"Malformed JSON", "POST", "/users", `{"name": `, http.StatusBadRequest, "/problems/malformed-body"},
+		{"Wrong Field Type", "POST", "/users", `{"name": 42}`, http.StatusUnprocessableEntity, "/problems/validation-error"},
+		{"Unknown Field", "POST", "/users", `{"name": "Jane Doe", "phone": "555-0100"}`, http.StatusUnprocessableEntity, "/problems/validation-error"},
+		{"Not An Object", "POST", "/users", `["Jane Doe"]`, http.StatusBadRequest, "/problems/malformed-body"},
+		{"Empty Body", "PUT", "/users/1", "", http.StatusBadRequest, "/problems/malformed-body"},
+		{"Validation", "POST", "/users", `{}`, http.StatusUnprocessableEntity, "/problems/validation-error"},
+		{"Unknown Route", "GET", "/groups", "", http.StatusNotFound, "/problems/not-found"},
+		{"Wrong Method", "POST", "/users/1", "", http.StatusMethodNotAllowed, "/problems/method-not-allowed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, tt.url, bytes.NewBufferString(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tt.wantStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v", status, tt.wantStatus)
+			}
+			if ct := rr.Header().Get("Content-Type"); ct != "application/problem+json" {
+				t.Errorf("handler returned wrong content type: got %v", ct)
+			}
+
+			var p Problem
+			if err := json.NewDecoder(rr.Body).Decode(&p); err != nil {
+				t.Fatal(err)
+			}
+			if p.Type != tt.wantType || p.Status != tt.wantStatus || p.Title == "" {
+				t.Errorf("handler returned unexpected problem: got %+v", p)
+			}
+			if p.Instance != req.URL.Path {
+				t.Errorf("handler returned wrong instance: got %v want %v", p.Instance, req.URL.Path)
+			}
+			if p.RequestID == "" || p.RequestID != rr.Header().Get("X-Request-Id") {
+				t.Errorf("handler returned request ID %q, header %q", p.RequestID, rr.Header().Get("X-Request-Id"))
+			}
+		})
+	}
+}
+
+func TestProblemHidesInternalErrors(t *testing.T) {
+	router := chi.NewRouter()
+	NewHandler(brokenStore{}).Routes(router)
+
+	req, _ := http.NewRequest("GET", "/users/1", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusInternalServerError {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusInternalServerError)
+	}
+	if strings.Contains(rr.Body.String(), "10.0.0.5") {
+		t.Errorf("handler leaked the store error: %s", rr.Body)
+	}
+}
+
+func TestProblemRecoversPanics(t *testing.T) {
+	router := chi.NewRouter()
+	NewHandler(NewMemoryUserStore()).Routes(router)
+	router.Get("/boom", func(w http.ResponseWriter, r *http.Request) { panic("boom") })
+
+	req, _ := http.NewRequest("GET", "/boom", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	var p Problem
+	json.NewDecoder(rr.Body).Decode(&p)
+	if rr.Code != http.StatusInternalServerError || p.Type != "/problems/internal-error" {
+		t.Errorf("handler returned %v %+v, want an internal-error problem", rr.Code, p)
+	}
+}
+
+func TestProblemKeepsClientRequestID(t *testing.T) {
+	_, router := newTestRouter(t)
+
+	req, _ := http.NewRequest("GET", "/users/7", nil)
+	req.Header.Set("X-Request-Id", "trace-123")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	var p Problem
+	json.NewDecoder(rr.Body).Decode(&p)
+	if p.RequestID != "trace-123" {
+		t.Errorf("handler returned request ID %q, want trace-123", p.RequestID)
+	}
+}
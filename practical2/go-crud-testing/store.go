@@ -0,0 +1,155 @@
+// store.go
+package main
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+)
+
+var (
+	// ErrUserNotFound is returned by a UserStore when no user has the given ID.
+	ErrUserNotFound = errors.New("user not found")
+	// ErrEmailTaken is returned when another user already has the email.
+	ErrEmailTaken = errors.New("email address is already in use")
+)
+
+// UserStore persists users for the handlers. Emails, when set, are unique.
+type UserStore interface {
+	// List returns the page of users selected by q.
+	List(ctx context.Context, q ListQuery) (UserPage, error)
+	Get(ctx context.Context, id int) (User, error)
+	// Create assigns the user an ID and stores it.
+	Create(ctx context.Context, user User) (User, error)
+	// Update replaces the user with user.ID.
+	Update(ctx context.Context, user User) (User, error)
+	// Modify replaces the user with id by fn's result in one atomic step,
+	// so no other write can land between the read and the write. Nothing
+	// is stored when fn fails, and fn's error is returned unchanged.
+	Modify(ctx context.Context, id int, fn func(User) (User, error)) (User, error)
+	Delete(ctx context.Context, id int) error
+}
+
+// MemoryUserStore keeps users in a map. It is safe for concurrent use.
+type MemoryUserStore struct {
+	mu     sync.Mutex
+	users  map[int]User
+	nextID int
+}
+
+// NewMemoryUserStore returns an empty store whose first user gets ID 1.
+func NewMemoryUserStore() *MemoryUserStore {
+	return &MemoryUserStore{users: make(map[int]User), nextID: 1}
+}
+
+func (s *MemoryUserStore) List(ctx context.Context, q ListQuery) (UserPage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	userList := make([]User, 0, len(s.users))
+	for _, user := range s.users {
+		if q.matches(user) {
+			userList = append(userList, user)
+		}
+	}
+	sort.Slice(userList, func(i, j int) bool { return q.less(userList[i], userList[j]) })
+	page := UserPage{Total: len(userList)}
+
+	start := min(q.Offset, len(userList))
+	if c := q.Cursor; c != nil {
+		last := User{ID: c.ID, Name: c.Name}
+		start = sort.Search(len(userList), func(i int) bool { return q.less(last, userList[i]) })
+	}
+	end := len(userList)
+	if q.Limit > 0 && start+q.Limit < end {
+		end = start + q.Limit
+		page.Next = cursorAfter(q, userList[end-1])
+	}
+
+	page.Users = userList[start:end]
+	return page, nil
+}
+
+func (s *MemoryUserStore) Get(ctx context.Context, id int) (User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[id]
+	if !ok {
+		return User{}, ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (s *MemoryUserStore) Create(ctx context.Context, user User) (User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.emailTaken(user) {
+		return User{}, ErrEmailTaken
+	}
+	user.ID = s.nextID
+	s.nextID++
+	s.users[user.ID] = user
+	return user, nil
+}
+
+func (s *MemoryUserStore) Update(ctx context.Context, user User) (User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[user.ID]; !ok {
+		return User{}, ErrUserNotFound
+	}
+	if s.emailTaken(user) {
+		return User{}, ErrEmailTaken
+	}
+	s.users[user.ID] = user
+	return user, nil
+}
+
+func (s *MemoryUserStore) Modify(ctx context.Context, id int, fn func(User) (User, error)) (User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[id]
+	if !ok {
+		return User{}, ErrUserNotFound
+	}
+	user, err := fn(user)
+	if err != nil {
+		return User{}, err
+	}
+	user.ID = id
+	if s.emailTaken(user) {
+		return User{}, ErrEmailTaken
+	}
+	s.users[id] = user
+	return user, nil
+}
+
+// emailTaken reports whether a user other than user has its email. s.mu
+// must be held.
+func (s *MemoryUserStore) emailTaken(user User) bool {
+	if user.Email == "" {
+		return false
+	}
+	for _, other := range s.users {
+		if other.ID != user.ID && other.Email == user.Email {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *MemoryUserStore) Delete(ctx context.Context, id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[id]; !ok {
+		return ErrUserNotFound
+	}
+	delete(s.users, id)
+	return nil
+}
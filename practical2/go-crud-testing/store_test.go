@@ -0,0 +1,180 @@
+// store_test.go
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// testUserStore checks the behaviour every UserStore must share.
+func testUserStore(t *testing.T, store UserStore) {
+	ctx := context.Background()
+
+	alice, err := store.Create(ctx, User{Name: "Alice", Email: "alice@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, _ := store.Create(ctx, User{Name: "Bob", Email: "bob@example.com"})
+	if alice.ID == 0 || bob.ID == alice.ID {
+		t.Fatalf("store assigned bad IDs: got %v and %v", alice.ID, bob.ID)
+	}
+
+	got, err := store.Get(ctx, alice.ID)
+	if err != nil || got.Name != "Alice" {
+		t.Errorf("Get returned %v (%v), want Alice", got, err)
+	}
+
+	if _, err := store.Update(ctx, User{ID: bob.ID, Name: "Robert", Email: "bob@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Emails are unique across users
+	if _, err := store.Create(ctx, User{Name: "Alice Again", Email: "alice@example.com"}); !errors.Is(err, ErrEmailTaken) {
+		t.Errorf("Create with a taken email returned %v, want ErrEmailTaken", err)
+	}
+	if _, err := store.Update(ctx, User{ID: bob.ID, Name: "Robert", Email: "alice@example.com"}); !errors.Is(err, ErrEmailTaken) {
+		t.Errorf("Update to a taken email returned %v, want ErrEmailTaken", err)
+	}
+	page, _ := store.List(ctx, ListQuery{})
+	userList := page.Users
+	if len(userList) != 2 || userList[0].ID != alice.ID || userList[1].Name != "Robert" {
+		t.Errorf("List returned %v, want Alice then Robert", userList)
+	}
+
+	if err := store.Delete(ctx, alice.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get(ctx, alice.ID); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Get after Delete returned %v, want ErrUserNotFound", err)
+	}
+	if _, err := store.Update(ctx, User{ID: alice.ID, Name: "Ghost", Email: "ghost@example.com"}); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Update of a deleted user returned %v, want ErrUserNotFound", err)
+	}
+	if err := store.Delete(ctx, alice.ID); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("second Delete returned %v, want ErrUserNotFound", err)
+	}
+}
+
+// testUserStoreModify checks that Modify is atomic: concurrent
+// read-modify-write cycles must not lose each other's changes.
+func testUserStoreModify(t *testing.T, store UserStore) {
+	ctx := context.Background()
+	user, err := store.Create(ctx, User{Name: "A", Email: "a@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := store.Modify(ctx, user.ID, func(u User) (User, error) {
+				u.Name += "a"
+				return u, nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got, _ := store.Get(ctx, user.ID); got.Name != "A"+strings.Repeat("a", 20) {
+		t.Errorf("concurrent Modify calls left name %q, want all 20 changes", got.Name)
+	}
+
+	// A failing fn stores nothing and its error is passed back
+	errStop := errors.New("stop")
+	_, err = store.Modify(ctx, user.ID, func(u User) (User, error) {
+		u.Name = "Changed"
+		return u, errStop
+	})
+	if !errors.Is(err, errStop) {
+		t.Errorf("Modify returned %v, want the error from fn", err)
+	}
+	if got, _ := store.Get(ctx, user.ID); got.Name == "Changed" {
+		t.Error("Modify stored the user even though fn failed")
+	}
+
+	_, err = store.Modify(ctx, user.ID+1000, func(u User) (User, error) { return u, nil })
+	if !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Modify of a missing user returned %v, want ErrUserNotFound", err)
+	}
+}
+
+// testUserStorePaging checks sorting, filtering and both kinds of
+// pagination. The store must be empty.
+func testUserStorePaging(t *testing.T, store UserStore) {
+	ctx := context.Background()
+	for i, name := range []string{"Carol", "alice", "Bob", "Alan", "Bob", "Dave_1"} {
+		email := fmt.Sprintf("user%d@example.com", i+1)
+		if _, err := store.Create(ctx, User{Name: name, Email: email}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	names := func(users []User) string {
+		var s []string
+		for _, u := range users {
+			s = append(s, fmt.Sprintf("%s#%d", u.Name, u.ID))
+		}
+		return strings.Join(s, ",")
+	}
+
+	tests := []struct {
+		name  string
+		query ListQuery
+		want  string
+		total int
+	}{
+		{"id ascending", ListQuery{}, "Carol#1,alice#2,Bob#3,Alan#4,Bob#5,Dave_1#6", 6},
+		{"id descending", ListQuery{Desc: true, Limit: 2}, "Dave_1#6,Bob#5", 6},
+		{"name ascending", ListQuery{Sort: SortByName}, "Alan#4,Bob#3,Bob#5,Carol#1,Dave_1#6,alice#2", 6},
+		{"name descending", ListQuery{Sort: SortByName, Desc: true, Limit: 3}, "alice#2,Dave_1#6,Carol#1", 6},
+		{"offset", ListQuery{Sort: SortByName, Offset: 2, Limit: 2}, "Bob#5,Carol#1", 6},
+		{"offset past the end", ListQuery{Offset: 10}, "", 6},
+		{"prefix ignores case", ListQuery{NamePrefix: "al"}, "alice#2,Alan#4", 2},
+		{"contains", ListQuery{NameContains: "O"}, "Carol#1,Bob#3,Bob#5", 3},
+		{"wildcards are literal", ListQuery{NameContains: "_"}, "Dave_1#6", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := store.List(ctx, tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := names(page.Users); got != tt.want || page.Total != tt.total {
+				t.Errorf("got %s (total %d), want %s (total %d)", got, page.Total, tt.want, tt.total)
+			}
+		})
+	}
+
+	// Walking with cursors visits every user once, even across equal names
+	q := ListQuery{Sort: SortByName, Limit: 2}
+	var seen []User
+	for {
+		page, err := store.List(ctx, q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen = append(seen, page.Users...)
+		if page.Next == nil {
+			break
+		}
+		q.Cursor = page.Next
+	}
+	if got := names(seen); got != "Alan#4,Bob#3,Bob#5,Carol#1,Dave_1#6,alice#2" {
+		t.Errorf("cursor walk visited %s", got)
+	}
+}
+
+func TestMemoryUserStore(t *testing.T) {
+	testUserStore(t, NewMemoryUserStore())
+	testUserStoreModify(t, NewMemoryUserStore())
+	testUserStorePaging(t, NewMemoryUserStore())
+}
@@ -0,0 +1,170 @@
+// validation.go
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// FieldError describes one field that failed validation.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ValidationErrors lists every failing field of a request.
+type ValidationErrors []FieldError
+
+func (v ValidationErrors) Error() string {
+	msgs := make([]string, len(v))
+	for i, fe := range v {
+		msgs[i] = fe.Message
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// decodeFields decodes the JSON object in data into the struct v points
+// to. Members that match no json tag and values of the wrong type are
+// reported together as ValidationErrors; malformed JSON and bodies that
+// are not objects are left for problemFor to report as malformed.
+func decodeFields(data []byte, v any) error {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return newProblem(http.StatusBadRequest, "malformed-body", "Malformed request body",
+				"request body must be a JSON object")
+		}
+		return err
+	}
+
+	rv := reflect.ValueOf(v).Elem()
+	rt := rv.Type()
+	fields := make(map[string]int, rt.NumField())
+	for i := 0; i < rt.NumField(); i++ {
+		name, _, _ := strings.Cut(rt.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = i
+		}
+	}
+
+	var errs ValidationErrors
+	for key, raw := range obj {
+		i, ok := fields[key]
+		if !ok {
+			errs = append(errs, FieldError{Field: key, Code: "unknown_field", Message: key + " is not a known field"})
+			continue
+		}
+		if err := json.Unmarshal(raw, rv.Field(i).Addr().Interface()); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if !errors.As(err, &typeErr) {
+				return err
+			}
+			errs = append(errs, FieldError{Field: key, Code: "invalid_type", Message: fmt.Sprintf("%s must be a %s", key, typeErr.Type)})
+		}
+	}
+	if len(errs) > 0 {
+		sort.Slice(errs, func(i, j int) bool { return errs[i].Field < errs[j].Field })
+		return errs
+	}
+	return nil
+}
+
+// charsets are the named character sets usable in a chars= rule.
+var charsets = map[string]struct {
+	allowed     func(r rune) bool
+	description string
+}{
+	"name": {
+		allowed: func(r rune) bool {
+			return unicode.IsLetter(r) || unicode.IsMark(r) || r == ' ' || r == '\'' || r == '-' || r == '.'
+		},
+		description: "letters, spaces, apostrophes, hyphens and periods",
+	},
+}
+
+// validate checks the string fields of the struct v against their
+// `validate` tags and reports every failure. Rules are comma-separated:
+//
+//	required  the value must contain something other than whitespace
+//	min=N     at least N characters
+//	max=N     at most N characters
+//	chars=S   only characters from the named charset S
+//	email     empty, or a bare address such as ana@example.com
+//
+// Field names in errors come from the json tag.
+func validate(v any) ValidationErrors {
+	var errs ValidationErrors
+	rv := reflect.ValueOf(v)
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		tag := sf.Tag.Get("validate")
+		if tag == "" || sf.Type.Kind() != reflect.String {
+			continue
+		}
+		field, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if field == "" {
+			field = sf.Name
+		}
+		if fe, ok := checkField(field, rv.Field(i).String(), tag); !ok {
+			errs = append(errs, fe)
+		}
+	}
+	return errs
+}
+
+// checkField applies the rules in order and returns the first failure, so
+// each field is reported once.
+func checkField(field, value, rules string) (FieldError, bool) {
+	length := utf8.RuneCountInString(value)
+
+	for _, rule := range strings.Split(rules, ",") {
+		name, arg, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			if strings.TrimSpace(value) == "" {
+				return FieldError{Field: field, Code: "required", Message: field + " is required"}, false
+			}
+		case "min":
+			n, _ := strconv.Atoi(arg)
+			if length < n {
+				return FieldError{Field: field, Code: "too_short", Message: fmt.Sprintf("%s must be at least %d characters", field, n)}, false
+			}
+		case "max":
+			n, _ := strconv.Atoi(arg)
+			if length > n {
+				return FieldError{Field: field, Code: "too_long", Message: fmt.Sprintf("%s must be at most %d characters", field, n)}, false
+			}
+		case "chars":
+			cs, ok := charsets[arg]
+			if !ok {
+				panic("validate: unknown charset " + arg)
+			}
+			if strings.IndexFunc(value, func(r rune) bool { return !cs.allowed(r) }) >= 0 {
+				return FieldError{Field: field, Code: "invalid_characters", Message: fmt.Sprintf("%s may only contain %s", field, cs.description)}, false
+			}
+		case "email":
+			if value == "" {
+				continue
+			}
+			if addr, err := mail.ParseAddress(value); err != nil || addr.Address != value {
+				return FieldError{Field: field, Code: "invalid_email", Message: field + " must be an email address"}, false
+			}
+		default:
+			panic("validate: unknown rule " + name)
+		}
+	}
+	return FieldError{}, true
+}
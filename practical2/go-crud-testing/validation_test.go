@@ -0,0 +1,125 @@
+// validation_test.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestValidateUser(t *testing.T) {
+	tests := []struct {
+		name     string
+		userName string
+		wantCode string
+	}{
+		{"Valid", "John Doe", ""},
+		{"Valid With Punctuation", "Mary-Jane O'Neil Jr.", ""},
+		{"Valid Accented", "José Müller", ""},
+		{"Missing", "", "required"},
+		{"Whitespace Only", "   ", "required"},
+		{"Too Short", "J", "too_short"},
+		{"Too Long", strings.Repeat("a", 101), "too_long"},
+		{"Longest Allowed", strings.Repeat("é", 100), ""},
+		{"Digits", "R2D2", "invalid_characters"},
+		{"Markup", "<script>", "invalid_characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validate(User{Name: tt.userName})
+			code := ""
+			if len(errs) > 0 {
+				code = errs[0].Code
+			}
+			if code != tt.wantCode {
+				t.Errorf("validate returned code %q, want %q (%v)", code, tt.wantCode, errs)
+			}
+			if len(errs) > 0 && errs[0].Field != "name" {
+				t.Errorf("validate returned field %q, want name", errs[0].Field)
+			}
+		})
+	}
+}
+
+func TestValidateReportsEveryField(t *testing.T) {
+	type form struct {
+		First string `json:"first" validate:"required"`
+		Last  string `json:"last" validate:"required,max=3"`
+		Notes string `json:"notes"`
+	}
+
+	errs := validate(form{Last: "Long"})
+	if len(errs) != 2 || errs[0].Field != "first" || errs[1].Code != "too_long" {
+		t.Errorf("validate returned %v, want first required and last too long", errs)
+	}
+}
+
+func TestDecodeFieldsReportsTypesAndUnknownMembers(t *testing.T) {
+	var user User
+	err := decodeFields([]byte(`{"name": 123, "id": "1", "phone": "555-0100", "email": "jane@example.com"}`), &user)
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 3 {
+		t.Fatalf("decodeFields returned %v, want three field errors", err)
+	}
+	want := []FieldError{
+		{Field: "id", Code: "invalid_type"},
+		{Field: "name", Code: "invalid_type"},
+		{Field: "phone", Code: "unknown_field"},
+	}
+	for i, fe := range errs {
+		if fe.Field != want[i].Field || fe.Code != want[i].Code {
+			t.Errorf("error %d is %s/%s, want %s/%s", i, fe.Field, fe.Code, want[i].Field, want[i].Code)
+		}
+	}
+	if user.Email != "jane@example.com" {
+		t.Errorf("decodeFields left email %q, want the valid members decoded", user.Email)
+	}
+}
+
+func TestUserValidationResponses(t *testing.T) {
+	_, router := newTestRouter(t, User{Name: "Jane Doe"})
+
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		body       string
+		wantStatus int
+	}{
+		{"Create Empty Object", "POST", "/users", `{}`, http.StatusUnprocessableEntity},
+		{"Create Bad Characters", "POST", "/users", `{"name": "x1"}`, http.StatusUnprocessableEntity},
+		{"Update Empty Name", "PUT", "/users/1", `{"name": ""}`, http.StatusUnprocessableEntity},
+		{"Create Huge Body", "POST", "/users", `{"name": "` + strings.Repeat("a", 2<<20) + `"}`, http.StatusRequestEntityTooLarge},
+		{"Create Valid", "POST", "/users", `{"name": "John Doe"}`, http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, tt.url, bytes.NewBufferString(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tt.wantStatus {
+				t.Fatalf("handler returned wrong status code: got %v want %v", status, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusUnprocessableEntity {
+				return
+			}
+
+			var body struct {
+				Errors []FieldError `json:"errors"`
+			}
+			json.NewDecoder(rr.Body).Decode(&body)
+			if len(body.Errors) != 1 || body.Errors[0].Field != "name" || body.Errors[0].Message == "" {
+				t.Errorf("handler returned unexpected errors: got %+v", body.Errors)
+			}
+		})
+	}
+}
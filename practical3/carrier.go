@@ -0,0 +1,158 @@
+// carrier.go
+package shipping
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrShipmentNotFound is returned when a carrier has no shipment with the given tracking number.
+var ErrShipmentNotFound = errors.New("shipment not found")
+
+// Booking confirms that a carrier has accepted a shipment.
+type Booking struct {
+	TrackingNumber string    `json:"tracking_number"`
+	Quote          Quote     `json:"quote"`
+	BookedAt       time.Time `json:"booked_at"`
+}
+
+// Carrier is the contract every carrier integration implements, whether it
+// is our own calculators or an external carrier's API.
+type Carrier interface {
+	// Rate prices a request without booking it.
+	Rate(ctx context.Context, req QuoteRequest) (Quote, error)
+	// Book prices the request and creates a shipment for it.
+	Book(ctx context.Context, req QuoteRequest) (Booking, error)
+	// Cancel withdraws a shipment that has not been picked up yet.
+	Cancel(ctx context.Context, trackingNumber string) error
+	// Track returns the shipment's status history, oldest first.
+	Track(ctx context.Context, trackingNumber string) ([]ShipmentEvent, error)
+}
+
+// CalculatorCarrier adapts a Calculator to the Carrier interface, keeping
+// booked shipments in memory. It is safe for concurrent use.
+type CalculatorCarrier struct {
+	Calculator *Calculator
+	Tracking   *TrackingGenerator
+	// Now is used instead of time.Now when set.
+	Now func() time.Time
+
+	mu        sync.Mutex
+	shipments map[string]*Shipment
+}
+
+// NewCalculatorCarrier wraps calc as a carrier issuing tracking numbers from gen.
+func NewCalculatorCarrier(calc *Calculator, gen *TrackingGenerator) *CalculatorCarrier {
+	return &CalculatorCarrier{
+		Calculator: calc,
+		Tracking:   gen,
+		shipments:  make(map[string]*Shipment),
+	}
+}
+
+// Rate implements Carrier.
+func (c *CalculatorCarrier) Rate(ctx context.Context, req QuoteRequest) (Quote, error) {
+	if err := ctx.Err(); err != nil {
+		return Quote{}, err
+	}
+	return c.Calculator.QuoteContext(ctx, req)
+}
+
+// Book implements Carrier.
+func (c *CalculatorCarrier) Book(ctx context.Context, req QuoteRequest) (Booking, error) {
+	q, err := c.Rate(ctx, req)
+	if err != nil {
+		return Booking{}, err
+	}
+
+	number, err := c.Tracking.Next()
+	if err != nil {
+		return Booking{}, err
+	}
+
+	now := c.now()
+	s, err := NewShipment(number, req, q, now)
+	if err != nil {
+		return Booking{}, err
+	}
+
+	c.mu.Lock()
+	c.shipments[number] = s
+	c.mu.Unlock()
+
+	return Booking{TrackingNumber: number, Quote: q, BookedAt: now}, nil
+}
+
+// Cancel implements Carrier.
+func (c *CalculatorCarrier) Cancel(ctx context.Context, trackingNumber string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.shipments[trackingNumber]
+	if !ok {
+		return ErrShipmentNotFound
+	}
+	if s.Status != StatusCreated {
+		return fmt.Errorf("%w: cannot cancel shipment %s: already %s", ErrInvalidTransition, trackingNumber, s.Status)
+	}
+
+	delete(c.shipments, trackingNumber)
+	return nil
+}
+
+// Track implements Carrier.
+func (c *CalculatorCarrier) Track(ctx context.Context, trackingNumber string) ([]ShipmentEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.shipments[trackingNumber]
+	if !ok {
+		return nil, ErrShipmentNotFound
+	}
+	return append([]ShipmentEvent(nil), s.Events...), nil
+}
+
+// Shipment returns a copy of a booked shipment. It lets the carrier act as
+// the ShipmentLookup for return labels.
+func (c *CalculatorCarrier) Shipment(trackingNumber string) (*Shipment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.shipments[trackingNumber]
+	if !ok {
+		return nil, ErrShipmentNotFound
+	}
+	shipment := *s
+	shipment.Events = append([]ShipmentEvent(nil), s.Events...)
+	return &shipment, nil
+}
+
+// Update records a status change reported for a booked shipment.
+func (c *CalculatorCarrier) Update(trackingNumber string, status ShipmentStatus, note string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.shipments[trackingNumber]
+	if !ok {
+		return ErrShipmentNotFound
+	}
+	return s.Transition(status, c.now(), note)
+}
+
+func (c *CalculatorCarrier) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
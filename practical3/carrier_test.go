@@ -0,0 +1,55 @@
+// carrier_test.go
+package shipping
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCalculatorCarrier(t *testing.T) {
+	ctx := context.Background()
+	var carrier Carrier = NewCalculatorCarrier(&Calculator{}, NewTrackingGenerator("SH", "BT", 1))
+	c := carrier.(*CalculatorCarrier)
+
+	// Rating does not create a shipment
+	q, err := carrier.Rate(ctx, QuoteRequest{Weight: 10, Zone: "Express"})
+	if err != nil || q.Total != 80 {
+		t.Fatalf("Expected an 80.00 quote, got %.2f, %v", q.Total, err)
+	}
+
+	booking, err := carrier.Book(ctx, QuoteRequest{Weight: 10, Zone: "Express"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !ValidTrackingNumber(booking.TrackingNumber) || booking.Quote.Total != 80 {
+		t.Errorf("Unexpected booking: %+v", booking)
+	}
+
+	events, err := carrier.Track(ctx, booking.TrackingNumber)
+	if err != nil || len(events) != 1 || events[0].Status != StatusCreated {
+		t.Errorf("Expected a single created event, got %+v, %v", events, err)
+	}
+
+	// Once picked up the shipment can no longer be cancelled
+	if err := c.Update(booking.TrackingNumber, StatusPickedUp, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := carrier.Cancel(ctx, booking.TrackingNumber); err == nil {
+		t.Error("Expected an error cancelling a picked-up shipment")
+	}
+
+	// A fresh booking can be cancelled and then disappears
+	second, _ := carrier.Book(ctx, QuoteRequest{Weight: 1, Zone: "Domestic"})
+	if err := carrier.Cancel(ctx, second.TrackingNumber); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := carrier.Track(ctx, second.TrackingNumber); !errors.Is(err, ErrShipmentNotFound) {
+		t.Errorf("Expected ErrShipmentNotFound, got %v", err)
+	}
+
+	// Invalid requests are not booked
+	if _, err := carrier.Book(ctx, QuoteRequest{Weight: 100, Zone: "Domestic"}); err == nil {
+		t.Error("Expected an error booking an overweight parcel")
+	}
+}
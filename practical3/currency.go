@@ -0,0 +1,177 @@
+// currency.go
+package shipping
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Currency describes how amounts in an ISO 4217 currency are presented.
+type Currency struct {
+	Code       string
+	MinorUnits int // digits after the decimal point, e.g. 2 for USD, 0 for JPY
+}
+
+// currencies lists the currencies we sell in. Anything else is rejected
+// so we never guess at minor units.
+var currencies = map[string]Currency{
+	"USD": {"USD", 2},
+	"EUR": {"EUR", 2},
+	"GBP": {"GBP", 2},
+	"AUD": {"AUD", 2},
+	"CAD": {"CAD", 2},
+	"INR": {"INR", 2},
+	"BTN": {"BTN", 2},
+	"SGD": {"SGD", 2},
+	"JPY": {"JPY", 0},
+	"KRW": {"KRW", 0},
+	"KWD": {"KWD", 3},
+}
+
+// LookupCurrency returns the currency for an ISO 4217 code.
+func LookupCurrency(code string) (Currency, error) {
+	c, ok := currencies[strings.ToUpper(code)]
+	if !ok {
+		return Currency{}, fmt.Errorf("unsupported currency: %s", code)
+	}
+	return c, nil
+}
+
+// Round rounds amount to the currency's minor unit.
+func (c Currency) Round(amount float64) float64 {
+	scale := math.Pow10(c.MinorUnits)
+	return math.Round(amount*scale) / scale
+}
+
+// ExchangeRate is the number of units of Currency one unit of the base buys.
+type ExchangeRate struct {
+	Currency string    `json:"currency"`
+	Rate     float64   `json:"rate"`
+	AsOf     time.Time `json:"as_of"`
+}
+
+// ExchangeRates is a table of rates from a single base currency.
+type ExchangeRates struct {
+	Base  string
+	rates map[string]ExchangeRate
+}
+
+// NewExchangeRates returns an empty table for the given base currency.
+func NewExchangeRates(base string) (*ExchangeRates, error) {
+	c, err := LookupCurrency(base)
+	if err != nil {
+		return nil, err
+	}
+	return &ExchangeRates{Base: c.Code, rates: make(map[string]ExchangeRate)}, nil
+}
+
+// LoadExchangeRates reads a CSV table with the columns currency,rate,as_of
+// (as_of in RFC 3339). A header row is allowed. When a currency appears more
+// than once the most recent rate is kept.
+func LoadExchangeRates(base string, r io.Reader) (*ExchangeRates, error) {
+	table, err := NewExchangeRates(base)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+
+	line := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading exchange rates: %w", err)
+		}
+		line++
+		if line == 1 && strings.EqualFold(record[0], "currency") {
+			continue
+		}
+
+		rate, err := strconv.ParseFloat(record[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("exchange rates line %d: invalid rate %q", line, record[1])
+		}
+		asOf, err := time.Parse(time.RFC3339, record[2])
+		if err != nil {
+			return nil, fmt.Errorf("exchange rates line %d: invalid timestamp %q", line, record[2])
+		}
+		if err := table.Set(ExchangeRate{Currency: record[0], Rate: rate, AsOf: asOf}); err != nil {
+			return nil, fmt.Errorf("exchange rates line %d: %w", line, err)
+		}
+	}
+
+	return table, nil
+}
+
+// Set stores rate unless a newer rate for the same currency is already present.
+func (t *ExchangeRates) Set(rate ExchangeRate) error {
+	c, err := LookupCurrency(rate.Currency)
+	if err != nil {
+		return err
+	}
+	if rate.Rate <= 0 {
+		return errors.New("exchange rate must be positive")
+	}
+
+	rate.Currency = c.Code
+	if existing, ok := t.rates[c.Code]; ok && existing.AsOf.After(rate.AsOf) {
+		return nil
+	}
+	t.rates[c.Code] = rate
+	return nil
+}
+
+// Rate returns the rate from the base currency to code.
+func (t *ExchangeRates) Rate(code string) (ExchangeRate, error) {
+	c, err := LookupCurrency(code)
+	if err != nil {
+		return ExchangeRate{}, err
+	}
+	if c.Code == t.Base {
+		return ExchangeRate{Currency: c.Code, Rate: 1}, nil
+	}
+	rate, ok := t.rates[c.Code]
+	if !ok {
+		return ExchangeRate{}, fmt.Errorf("no exchange rate from %s to %s", t.Base, c.Code)
+	}
+	return rate, nil
+}
+
+// Presentment is a quote converted into the customer's currency. Each line
+// is converted and rounded on its own, and Total is the sum of those lines,
+// so the presented figures always add up.
+type Presentment struct {
+	Currency string     `json:"currency"`
+	Rate     float64    `json:"rate"`
+	RateAsOf time.Time  `json:"rate_as_of"`
+	Lines    []LineItem `json:"lines"`
+	Total    float64    `json:"total"`
+}
+
+// Present converts a quote priced in the table's base currency into code.
+func (t *ExchangeRates) Present(q Quote, code string) (*Presentment, error) {
+	rate, err := t.Rate(code)
+	if err != nil {
+		return nil, err
+	}
+	c, _ := LookupCurrency(code)
+
+	p := &Presentment{Currency: c.Code, Rate: rate.Rate, RateAsOf: rate.AsOf}
+	for _, line := range q.Lines {
+		line.Amount = c.Round(line.Amount * rate.Rate)
+		p.Lines = append(p.Lines, line)
+		p.Total = c.Round(p.Total + line.Amount)
+	}
+	return p, nil
+}
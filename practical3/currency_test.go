@@ -0,0 +1,100 @@
+// currency_test.go
+package shipping
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleRates = `currency,rate,as_of
+EUR,0.92,2025-12-01T00:00:00Z
+JPY,151.237,2025-12-01T00:00:00Z
+EUR,0.90,2025-11-01T00:00:00Z
+KWD,0.3071,2025-12-01T00:00:00Z
+`
+
+func TestCurrency_Round(t *testing.T) {
+	testCases := []struct {
+		code     string
+		amount   float64
+		expected float64
+	}{
+		{"USD", 12.345, 12.35},
+		{"JPY", 2268.555, 2269},
+		{"KWD", 4.60651, 4.607},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.code, func(t *testing.T) {
+			c, err := LookupCurrency(tc.code)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := c.Round(tc.amount); got != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestLoadExchangeRates(t *testing.T) {
+	rates, err := LoadExchangeRates("USD", strings.NewReader(sampleRates))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The older EUR row must not overwrite the newer one
+	eur, err := rates.Rate("eur")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eur.Rate != 0.92 || !eur.AsOf.Equal(time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the newest EUR rate, got %+v", eur)
+	}
+
+	if _, err := rates.Rate("GBP"); err == nil {
+		t.Error("Expected an error for a currency with no rate")
+	}
+
+	// Malformed tables are rejected with the offending line
+	_, err = LoadExchangeRates("USD", strings.NewReader("EUR,abc,2025-12-01T00:00:00Z\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("Expected a line-numbered error, got %v", err)
+	}
+	if _, err := LoadExchangeRates("USD", strings.NewReader("XXX,1,2025-12-01T00:00:00Z\n")); err == nil {
+		t.Error("Expected an error for an unsupported currency")
+	}
+}
+
+func TestCalculator_QuotePresentment(t *testing.T) {
+	rates, err := LoadExchangeRates("USD", strings.NewReader(sampleRates))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := Calculator{Rates: rates}
+
+	// 15.00 USD at 151.237 is 2268.555 JPY, which has no minor unit
+	q, err := c.Quote(QuoteRequest{Weight: 10, Zone: "Domestic", Currency: "JPY"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if q.Currency != "USD" || q.Total != 15 {
+		t.Errorf("Expected base total 15.00 USD, got %.2f %s", q.Total, q.Currency)
+	}
+	if q.Presentment == nil || q.Presentment.Total != 2269 || q.Presentment.Currency != "JPY" {
+		t.Errorf("Expected presentment total 2269 JPY, got %+v", q.Presentment)
+	}
+
+	// Paying in the base currency needs no conversion
+	q, err = c.Quote(QuoteRequest{Weight: 10, Zone: "Domestic", Currency: "usd"})
+	if err != nil || q.Presentment != nil {
+		t.Errorf("Expected no presentment for the base currency, got %+v, %v", q.Presentment, err)
+	}
+
+	// Without a rate table foreign currencies cannot be quoted
+	var plain Calculator
+	if _, err := plain.Quote(QuoteRequest{Weight: 10, Zone: "Domestic", Currency: "EUR"}); err == nil {
+		t.Error("Expected an error when no exchange rates are loaded")
+	}
+}
@@ -0,0 +1,127 @@
+// customs.go
+package shipping
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CustomsItem is one line of a customs declaration.
+type CustomsItem struct {
+	Description string  `json:"description"`
+	HSCode      string  `json:"hs_code"`
+	Value       float64 `json:"value"` // declared value of all units, in the base currency
+	Quantity    int     `json:"quantity"`
+	Origin      string  `json:"origin"` // ISO 3166 alpha-2 country of origin
+}
+
+// DestinationRules are the import rules of one destination country.
+type DestinationRules struct {
+	// DeMinimis is the consignment value at or below which no duty or tax is due.
+	DeMinimis float64 `json:"de_minimis"`
+	// DutyRates maps HS code prefixes to duty rates (0.05 = 5%). The longest
+	// matching prefix wins; DefaultDuty applies when none matches.
+	DutyRates   map[string]float64 `json:"duty_rates"`
+	DefaultDuty float64            `json:"default_duty"`
+	// DutyFree lists origin countries with a trade agreement waiving duty.
+	DutyFree []string `json:"duty_free"`
+	// VATRate is the import VAT charged on value plus shipping plus duty.
+	VATRate float64 `json:"vat_rate"`
+}
+
+// CustomsLine is the duty assessed on one item.
+type CustomsLine struct {
+	HSCode   string  `json:"hs_code"`
+	Value    float64 `json:"value"`
+	DutyRate float64 `json:"duty_rate"`
+	Duty     float64 `json:"duty"`
+}
+
+// LandedCost is the customs estimate for a consignment. It is reported
+// separately from the shipping fee because it is paid to the destination's
+// customs authority, not to us.
+type LandedCost struct {
+	Destination string        `json:"destination"`
+	GoodsValue  float64       `json:"goods_value"`
+	DeMinimis   bool          `json:"de_minimis"`
+	Lines       []CustomsLine `json:"lines"`
+	Duty        float64       `json:"duty"`
+	VAT         float64       `json:"vat"`
+	Total       float64       `json:"total"`
+}
+
+// CustomsTariff holds the import rules per destination country.
+type CustomsTariff map[string]DestinationRules
+
+// Estimate computes duty and import VAT for items shipped to destination.
+// shippingFee is included in the VAT base, as most customs authorities do.
+func (t CustomsTariff) Estimate(destination string, items []CustomsItem, shippingFee float64) (LandedCost, error) {
+	destination = strings.ToUpper(destination)
+	rules, ok := t[destination]
+	if !ok {
+		return LandedCost{}, fieldError("destination", MsgCustomsNoRules, destination)
+	}
+	if len(items) == 0 {
+		return LandedCost{}, fieldError("items", MsgCustomsNoItems)
+	}
+
+	lc := LandedCost{Destination: destination}
+	for i, item := range items {
+		if err := item.validate(i + 1); err != nil {
+			err.Field = fmt.Sprintf("items[%d].%s", i, err.Field)
+			return LandedCost{}, err
+		}
+		lc.GoodsValue = roundCents(lc.GoodsValue + item.Value)
+	}
+
+	if lc.GoodsValue <= rules.DeMinimis {
+		lc.DeMinimis = true
+		return lc, nil
+	}
+
+	for _, item := range items {
+		rate := rules.dutyRate(item)
+		duty := roundCents(item.Value * rate)
+		lc.Lines = append(lc.Lines, CustomsLine{HSCode: item.HSCode, Value: item.Value, DutyRate: rate, Duty: duty})
+		lc.Duty = roundCents(lc.Duty + duty)
+	}
+
+	lc.VAT = roundCents((lc.GoodsValue + shippingFee + lc.Duty) * rules.VATRate)
+	lc.Total = roundCents(lc.Duty + lc.VAT)
+	return lc, nil
+}
+
+// dutyRate finds the duty rate for an item, honouring duty-free origins.
+func (r DestinationRules) dutyRate(item CustomsItem) float64 {
+	for _, origin := range r.DutyFree {
+		if strings.EqualFold(origin, item.Origin) {
+			return 0
+		}
+	}
+
+	rate, best := r.DefaultDuty, 0
+	for prefix, prefixRate := range r.DutyRates {
+		if strings.HasPrefix(item.HSCode, prefix) && len(prefix) > best {
+			rate, best = prefixRate, len(prefix)
+		}
+	}
+	return rate
+}
+
+// validate checks the declaration fields customs will reject. n is the
+// item's position in the declaration, counting from one.
+func (item CustomsItem) validate(n int) *FieldError {
+	if len(item.HSCode) < 6 || strings.Trim(item.HSCode, "0123456789") != "" {
+		return fieldError("hs_code", MsgCustomsInvalidHSCode, n, item.HSCode)
+	}
+	if item.Value <= 0 {
+		return fieldError("value", MsgCustomsInvalidValue, n)
+	}
+	if item.Quantity <= 0 {
+		return fieldError("quantity", MsgCustomsInvalidQuantity, n)
+	}
+	if len(item.Origin) != 2 || !isLetters(item.Origin) {
+		return fieldError("origin", MsgCustomsInvalidOrigin, n, item.Origin)
+	}
+	return nil
+}
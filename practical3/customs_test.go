@@ -0,0 +1,116 @@
+// customs_test.go
+package shipping
+
+import (
+	"math"
+	"testing"
+)
+
+var testTariff = CustomsTariff{
+	"GB": {
+		DeMinimis:   135,
+		DutyRates:   map[string]float64{"61": 0.12, "6109": 0.08, "8471": 0},
+		DefaultDuty: 0.04,
+		DutyFree:    []string{"IE"},
+		VATRate:     0.20,
+	},
+}
+
+func TestCustomsTariff_Estimate(t *testing.T) {
+	testCases := []struct {
+		name         string
+		items        []CustomsItem
+		expectedDuty float64
+		expectedVAT  float64
+		deMinimis    bool
+	}{
+		{
+			name:      "At the de minimis threshold",
+			items:     []CustomsItem{{HSCode: "610910", Value: 135, Quantity: 3, Origin: "CN"}},
+			deMinimis: true,
+		},
+		{
+			// Longest prefix 6109 (8%) beats 61 (12%): duty 16, VAT (200 + 45 + 16) * 20%
+			name:         "Longest HS prefix wins",
+			items:        []CustomsItem{{HSCode: "610910", Value: 200, Quantity: 10, Origin: "CN"}},
+			expectedDuty: 16,
+			expectedVAT:  52.20,
+		},
+		{
+			// Default 4% on the first item, nothing on the Irish one
+			name: "Default rate and duty-free origin",
+			items: []CustomsItem{
+				{HSCode: "950300", Value: 100, Quantity: 1, Origin: "US"},
+				{HSCode: "610910", Value: 100, Quantity: 1, Origin: "IE"},
+			},
+			expectedDuty: 4,
+			expectedVAT:  49.80,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lc, err := testTariff.Estimate("gb", tc.items, 45)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if lc.DeMinimis != tc.deMinimis {
+				t.Errorf("Expected de minimis %v, got %v", tc.deMinimis, lc.DeMinimis)
+			}
+			if math.Abs(lc.Duty-tc.expectedDuty) > 0.001 || math.Abs(lc.VAT-tc.expectedVAT) > 0.001 {
+				t.Errorf("Expected duty %.2f and VAT %.2f, got %.2f and %.2f", tc.expectedDuty, tc.expectedVAT, lc.Duty, lc.VAT)
+			}
+		})
+	}
+}
+
+func TestCustomsTariff_Invalid(t *testing.T) {
+	valid := CustomsItem{HSCode: "610910", Value: 10, Quantity: 1, Origin: "CN"}
+
+	testCases := []struct {
+		name        string
+		destination string
+		item        CustomsItem
+	}{
+		{"Unknown destination", "FR", valid},
+		{"Short HS code", "GB", CustomsItem{HSCode: "6109", Value: 10, Quantity: 1, Origin: "CN"}},
+		{"Zero value", "GB", CustomsItem{HSCode: "610910", Value: 0, Quantity: 1, Origin: "CN"}},
+		{"Bad origin", "GB", CustomsItem{HSCode: "610910", Value: 10, Quantity: 1, Origin: "China"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := testTariff.Estimate(tc.destination, []CustomsItem{tc.item}, 0); err == nil {
+				t.Error("Expected an error, got nil")
+			}
+		})
+	}
+}
+
+func TestCalculator_QuoteCustoms(t *testing.T) {
+	c := Calculator{Tariff: testTariff}
+	items := []CustomsItem{{HSCode: "610910", Value: 200, Quantity: 10, Origin: "CN"}}
+
+	q, err := c.Quote(QuoteRequest{Weight: 10, Zone: "International", Destination: "GB", Items: items})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// The shipping fee itself is unchanged; customs is reported alongside it
+	if q.Total != 45 || q.Customs == nil || q.Customs.Total != 68.20 {
+		t.Errorf("Expected a 45.00 fee with 68.20 customs, got %+v", q)
+	}
+
+	// A carbon offset is added to the fee but not to the VAT base
+	q, err = c.Quote(QuoteRequest{Weight: 10, Zone: "International", Destination: "GB", Items: items, CarbonOffset: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if q.Total <= 45 || q.Customs == nil || q.Customs.VAT != 52.20 {
+		t.Errorf("Expected an offset on top of 45.00 and 52.20 VAT, got %+v", q)
+	}
+
+	// Domestic parcels are never assessed
+	q, _ = c.Quote(QuoteRequest{Weight: 10, Zone: "Domestic", Destination: "GB", Items: items})
+	if q.Customs != nil {
+		t.Error("Expected no customs estimate for a Domestic parcel")
+	}
+}
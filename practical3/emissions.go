@@ -0,0 +1,112 @@
+// emissions.go
+package shipping
+
+import "math"
+
+// TransportMode is how a parcel travels between depots.
+type TransportMode string
+
+const (
+	ModeRoad TransportMode = "road"
+	ModeAir  TransportMode = "air"
+)
+
+// Route is the distance and transport mode assumed for a zone.
+type Route struct {
+	Distance float64       `json:"distance_km"`
+	Mode     TransportMode `json:"mode"`
+}
+
+// Emissions is the estimated CO2-equivalent for one shipment.
+type Emissions struct {
+	ChargeableWeight float64       `json:"chargeable_weight_kg"`
+	Distance         float64       `json:"distance_km"`
+	Mode             TransportMode `json:"mode"`
+	CO2e             float64       `json:"co2e_kg"`
+}
+
+// EmissionsModel estimates shipment emissions from chargeable weight and
+// tonne-kilometres travelled, the approach used by the GLEC framework.
+type EmissionsModel struct {
+	// Routes gives the average distance and mode for each zone.
+	Routes map[string]Route
+	// Factors are kilograms of CO2e per tonne-kilometre for each mode.
+	Factors map[TransportMode]float64
+	// VolumetricDivisor converts cubic centimetres to volumetric kilograms.
+	// Zero means dimensions are ignored.
+	VolumetricDivisor float64
+	// OffsetPrice is what offsetting one kilogram of CO2e costs, in the
+	// base currency.
+	OffsetPrice float64
+}
+
+// DefaultEmissionsModel is used when a Calculator has no emissions model
+// configured. Express parcels fly; everything else goes by road. The
+// factors are typical published averages for parcel vans and short-haul
+// air freight, and the offset price is $15 per tonne.
+var DefaultEmissionsModel = EmissionsModel{
+	Routes: map[string]Route{
+		"Domestic":      {Distance: 300, Mode: ModeRoad},
+		"International": {Distance: 1500, Mode: ModeRoad},
+		"Express":       {Distance: 800, Mode: ModeAir},
+	},
+	Factors: map[TransportMode]float64{
+		ModeRoad: 0.11,
+		ModeAir:  0.60,
+	},
+	VolumetricDivisor: 5000,
+	OffsetPrice:       0.015,
+}
+
+// ChargeableWeight is the greater of the actual and volumetric weight.
+func (m EmissionsModel) ChargeableWeight(weight float64, dims *Dimensions) float64 {
+	if dims == nil || m.VolumetricDivisor <= 0 {
+		return weight
+	}
+	return math.Max(weight, dims.Length*dims.Width*dims.Height/m.VolumetricDivisor)
+}
+
+// Estimate returns the emissions for a parcel sent to zone. It reports
+// false when the model has no route for the zone.
+func (m EmissionsModel) Estimate(zone string, weight float64, dims *Dimensions) (Emissions, bool) {
+	route, ok := m.Routes[zone]
+	if !ok {
+		return Emissions{}, false
+	}
+	chargeable := m.ChargeableWeight(weight, dims)
+	co2e := chargeable / 1000 * route.Distance * m.Factors[route.Mode]
+	return Emissions{
+		ChargeableWeight: chargeable,
+		Distance:         route.Distance,
+		Mode:             route.Mode,
+		CO2e:             math.Round(co2e*1000) / 1000,
+	}, true
+}
+
+// OffsetLine returns the carbon offset fee for e, never less than a cent.
+func (m EmissionsModel) OffsetLine(e Emissions) LineItem {
+	return newLine("carbon_offset", math.Max(e.CO2e*m.OffsetPrice, 0.01))
+}
+
+// emissionsStage attaches the emissions estimate and, when asked for, the
+// carbon offset fee. An offset cannot be priced for a zone with no route.
+func emissionsStage(pc *PricingContext) error {
+	model := DefaultEmissionsModel
+	if pc.Calculator.Emissions != nil {
+		model = *pc.Calculator.Emissions
+	}
+
+	req := pc.Request
+	e, ok := model.Estimate(req.Zone, req.Weight, req.Dimensions)
+	if !ok {
+		if req.CarbonOffset {
+			return fieldError("carbon_offset", MsgOffsetNoRoute, req.Zone)
+		}
+		return nil
+	}
+	pc.Quote.Emissions = &e
+	if req.CarbonOffset {
+		pc.Quote.AddLine(model.OffsetLine(e))
+	}
+	return nil
+}
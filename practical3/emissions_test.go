@@ -0,0 +1,99 @@
+// emissions_test.go
+package shipping
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestChargeableWeight(t *testing.T) {
+	m := DefaultEmissionsModel
+
+	tests := []struct {
+		name   string
+		weight float64
+		dims   *Dimensions
+		want   float64
+	}{
+		{"no dimensions", 2, nil, 2},
+		{"dense parcel", 10, &Dimensions{Length: 30, Width: 20, Height: 10}, 10},
+		{"bulky parcel", 2, &Dimensions{Length: 50, Width: 40, Height: 30}, 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.ChargeableWeight(tt.weight, tt.dims); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEmissionsEstimate(t *testing.T) {
+	m := DefaultEmissionsModel
+
+	tests := []struct {
+		zone string
+		mode TransportMode
+		want float64
+	}{
+		// 10kg = 0.01t
+		{"Domestic", ModeRoad, 0.01 * 300 * 0.11},
+		{"International", ModeRoad, 0.01 * 1500 * 0.11},
+		{"Express", ModeAir, 0.01 * 800 * 0.60},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.zone, func(t *testing.T) {
+			e, ok := m.Estimate(tt.zone, 10, nil)
+			if !ok {
+				t.Fatal("Expected an estimate")
+			}
+			if e.Mode != tt.mode || math.Abs(e.CO2e-tt.want) > 0.0005 {
+				t.Errorf("Expected %.3fkg by %s, got %.3fkg by %s", tt.want, tt.mode, e.CO2e, e.Mode)
+			}
+		})
+	}
+
+	if _, ok := m.Estimate("Moon", 10, nil); ok {
+		t.Error("Expected no estimate for an unknown zone")
+	}
+}
+
+func TestQuoteEmissions(t *testing.T) {
+	c := &Calculator{}
+
+	q, err := c.Quote(QuoteRequest{Weight: 10, Zone: "Express"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if q.Emissions == nil || q.Emissions.CO2e != 4.8 {
+		t.Fatalf("Expected 4.8kg CO2e, got %+v", q.Emissions)
+	}
+	if q.Total != 80 {
+		t.Errorf("Expected no offset fee unless asked, got total %.2f", q.Total)
+	}
+
+	q, _ = c.Quote(QuoteRequest{Weight: 10, Zone: "Express", CarbonOffset: true})
+	last := q.Lines[len(q.Lines)-1]
+	if last.Code != "carbon_offset" || last.Amount != 0.07 || q.Total != 80.07 {
+		t.Errorf("Expected a 0.07 offset line, got %+v (total %.2f)", last, q.Total)
+	}
+
+	// Tiny footprints still cost a cent to offset
+	q, _ = c.Quote(QuoteRequest{Weight: 0.1, Zone: "Domestic", CarbonOffset: true})
+	if last := q.Lines[len(q.Lines)-1]; last.Amount != 0.01 {
+		t.Errorf("Expected a minimum offset of 0.01, got %.2f", last.Amount)
+	}
+
+	// An offset for a zone with no route cannot be priced
+	c.Emissions = &EmissionsModel{Routes: map[string]Route{}}
+	var fe *FieldError
+	if _, err := c.Quote(QuoteRequest{Weight: 10, Zone: "Express", CarbonOffset: true}); !errors.As(err, &fe) || fe.Code != MsgOffsetNoRoute {
+		t.Errorf("Expected %s, got %v", MsgOffsetNoRoute, err)
+	}
+	if _, err := c.Quote(QuoteRequest{Weight: 10, Zone: "Express"}); err != nil {
+		t.Errorf("Expected a quote without an offset, got %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+// errors.go
+package shipping
+
+import "errors"
+
+// FieldError reports a request field that failed validation. Its message
+// is the same text the calculators have always returned, so callers that
+// only print errors see no difference; API layers can use Field to point
+// at the offending input, and Code and Args to show the message in the
+// customer's language.
+type FieldError struct {
+	Field   string
+	Code    string
+	Args    []any
+	Message string
+	Err     error
+}
+
+func (e *FieldError) Error() string { return e.Message }
+func (e *FieldError) Unwrap() error { return e.Err }
+
+// NewFieldError builds a FieldError whose message is the English text for
+// code, for API layers that validate their own input.
+func NewFieldError(field, code string, args ...any) *FieldError {
+	return &FieldError{Field: field, Code: code, Args: args, Message: Messages.Format(DefaultLocale, code, args...)}
+}
+
+func fieldError(field, code string, args ...any) *FieldError {
+	return NewFieldError(field, code, args...)
+}
+
+// IsValidation reports whether err was caused by invalid input rather than
+// a failure in the system.
+func IsValidation(err error) bool {
+	var fe *FieldError
+	return errors.As(err, &fe)
+}
@@ -0,0 +1,102 @@
+// client.go
+package fakecarrier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"shipping"
+)
+
+// Client talks to a carrier API over HTTP and implements shipping.Carrier.
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+var _ shipping.Carrier = (*Client)(nil)
+
+// StatusError is returned when the carrier answers with a non-success status.
+type StatusError struct {
+	Status  int
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("carrier returned %d: %s", e.Status, e.Message)
+}
+
+// Rate implements shipping.Carrier.
+func (c *Client) Rate(ctx context.Context, req shipping.QuoteRequest) (shipping.Quote, error) {
+	var q shipping.Quote
+	err := c.do(ctx, http.MethodPost, "/rates", req, http.StatusOK, &q)
+	return q, err
+}
+
+// Book implements shipping.Carrier.
+func (c *Client) Book(ctx context.Context, req shipping.QuoteRequest) (shipping.Booking, error) {
+	var b shipping.Booking
+	err := c.do(ctx, http.MethodPost, "/shipments", req, http.StatusCreated, &b)
+	return b, err
+}
+
+// Cancel implements shipping.Carrier.
+func (c *Client) Cancel(ctx context.Context, trackingNumber string) error {
+	return c.do(ctx, http.MethodDelete, "/shipments/"+url.PathEscape(trackingNumber), nil, http.StatusNoContent, nil)
+}
+
+// Track implements shipping.Carrier.
+func (c *Client) Track(ctx context.Context, trackingNumber string) ([]shipping.ShipmentEvent, error) {
+	var events []shipping.ShipmentEvent
+	err := c.do(ctx, http.MethodGet, "/shipments/"+url.PathEscape(trackingNumber)+"/events", nil, http.StatusOK, &events)
+	return events, err
+}
+
+// do sends one request and decodes the response into out.
+func (c *Client) do(ctx context.Context, method, path string, body any, want int, out any) error {
+	var reader *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != want {
+		var e errorBody
+		json.NewDecoder(resp.Body).Decode(&e)
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("%w: %s", shipping.ErrShipmentNotFound, e.Error)
+		}
+		return &StatusError{Status: resp.StatusCode, Message: e.Error}
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,212 @@
+// fakecarrier.go
+
+// Package fakecarrier runs an in-process HTTP carrier API so carrier
+// integrations can be tested offline. Latency and failures can be
+// programmed per operation.
+package fakecarrier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"shipping"
+)
+
+// Operation names one of the carrier API calls.
+type Operation string
+
+const (
+	OpRate   Operation = "rate"
+	OpBook   Operation = "book"
+	OpCancel Operation = "cancel"
+	OpTrack  Operation = "track"
+)
+
+// Fault describes how the fake should misbehave for an operation.
+type Fault struct {
+	// Latency delays the response. The delay ends early if the client gives up.
+	Latency time.Duration
+	// Status, when non-zero, is returned instead of handling the request.
+	Status int
+	// Times limits the fault to the next n requests. Zero means every request.
+	Times int
+}
+
+// Server is a fake carrier API backed by a shipping.Carrier.
+type Server struct {
+	URL string
+
+	carrier shipping.Carrier
+	http    *httptest.Server
+
+	mu     sync.Mutex
+	faults map[Operation]*Fault
+	calls  map[Operation]int
+}
+
+// NewServer starts a fake carrier serving c. Call Close when done.
+func NewServer(c shipping.Carrier) *Server {
+	s := &Server{
+		carrier: c,
+		faults:  make(map[Operation]*Fault),
+		calls:   make(map[Operation]int),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /rates", s.wrap(OpRate, s.handleRate))
+	mux.HandleFunc("POST /shipments", s.wrap(OpBook, s.handleBook))
+	mux.HandleFunc("DELETE /shipments/{tracking}", s.wrap(OpCancel, s.handleCancel))
+	mux.HandleFunc("GET /shipments/{tracking}/events", s.wrap(OpTrack, s.handleTrack))
+
+	s.http = httptest.NewServer(mux)
+	s.URL = s.http.URL
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.http.Close()
+}
+
+// Program installs a fault for op, replacing any existing one.
+func (s *Server) Program(op Operation, f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[op] = &f
+}
+
+// Reset clears all faults and call counts.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = make(map[Operation]*Fault)
+	s.calls = make(map[Operation]int)
+}
+
+// Calls returns how many requests have been made for op.
+func (s *Server) Calls(op Operation) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[op]
+}
+
+// takeFault counts the call and returns the fault to apply to it, if any.
+func (s *Server) takeFault(op Operation) (Fault, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls[op]++
+	f, ok := s.faults[op]
+	if !ok {
+		return Fault{}, false
+	}
+	if f.Times > 0 {
+		f.Times--
+		if f.Times == 0 {
+			delete(s.faults, op)
+		}
+	}
+	return *f, true
+}
+
+// wrap applies any programmed fault before calling the real handler.
+func (s *Server) wrap(op Operation, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f, ok := s.takeFault(op)
+		if ok && f.Latency > 0 {
+			select {
+			case <-time.After(f.Latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if ok && f.Status != 0 {
+			writeError(w, f.Status, "injected failure")
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *Server) handleRate(w http.ResponseWriter, r *http.Request) {
+	var req shipping.QuoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "malformed request")
+		return
+	}
+	q, err := s.carrier.Rate(r.Context(), req)
+	if err != nil {
+		writeCarrierError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, q)
+}
+
+func (s *Server) handleBook(w http.ResponseWriter, r *http.Request) {
+	var req shipping.QuoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "malformed request")
+		return
+	}
+	b, err := s.carrier.Book(r.Context(), req)
+	if err != nil {
+		writeCarrierError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, b)
+}
+
+func (s *Server) handleCancel(w http.ResponseWriter, r *http.Request) {
+	if err := s.carrier.Cancel(r.Context(), r.PathValue("tracking")); err != nil {
+		writeCarrierError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleTrack(w http.ResponseWriter, r *http.Request) {
+	events, err := s.carrier.Track(r.Context(), r.PathValue("tracking"))
+	if err != nil {
+		writeCarrierError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, events)
+}
+
+// errorBody is the JSON error format used by the fake carrier.
+type errorBody struct {
+	Error string `json:"error"`
+}
+
+// writeCarrierError maps carrier errors onto HTTP statuses. Only
+// validation failures are the caller's fault; anything unrecognised is a
+// 500 so clients do not mistake it for a bad request.
+func writeCarrierError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, shipping.ErrShipmentNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, shipping.ErrInvalidTransition):
+		writeError(w, http.StatusConflict, err.Error())
+	case shipping.IsValidation(err):
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		writeError(w, http.StatusGatewayTimeout, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorBody{Error: message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,111 @@
+// fakecarrier_test.go
+package fakecarrier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"shipping"
+)
+
+func newFake(t *testing.T) (*Server, *Client) {
+	t.Helper()
+	carrier := shipping.NewCalculatorCarrier(&shipping.Calculator{}, shipping.NewTrackingGenerator("SH", "BT", 1))
+	srv := NewServer(carrier)
+	t.Cleanup(srv.Close)
+	return srv, &Client{BaseURL: srv.URL}
+}
+
+func TestClient_RoundTrip(t *testing.T) {
+	_, client := newFake(t)
+	ctx := context.Background()
+
+	q, err := client.Rate(ctx, shipping.QuoteRequest{Weight: 10, Zone: "Domestic"})
+	if err != nil || q.Total != 15 {
+		t.Fatalf("Expected a 15.00 quote, got %.2f, %v", q.Total, err)
+	}
+
+	booking, err := client.Book(ctx, shipping.QuoteRequest{Weight: 10, Zone: "Domestic"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	events, err := client.Track(ctx, booking.TrackingNumber)
+	if err != nil || len(events) != 1 {
+		t.Fatalf("Expected one tracking event, got %+v, %v", events, err)
+	}
+
+	if err := client.Cancel(ctx, booking.TrackingNumber); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.Track(ctx, booking.TrackingNumber); !errors.Is(err, shipping.ErrShipmentNotFound) {
+		t.Errorf("Expected ErrShipmentNotFound, got %v", err)
+	}
+
+	// Validation errors from the calculator come back as 422
+	var statusErr *StatusError
+	_, err = client.Rate(ctx, shipping.QuoteRequest{Weight: 10, Zone: "Local"})
+	if !errors.As(err, &statusErr) || statusErr.Status != http.StatusUnprocessableEntity {
+		t.Errorf("Expected a 422 StatusError, got %v", err)
+	}
+}
+
+func TestWriteCarrierError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"Not found", shipping.ErrShipmentNotFound, http.StatusNotFound},
+		{"Invalid transition", fmt.Errorf("%w: delivered -> created", shipping.ErrInvalidTransition), http.StatusConflict},
+		{"Validation", &shipping.FieldError{Field: "zone", Code: shipping.MsgInvalidZone}, http.StatusUnprocessableEntity},
+		{"Timeout", context.DeadlineExceeded, http.StatusGatewayTimeout},
+		{"Internal", errors.New("rate table unavailable"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			writeCarrierError(rr, tt.err)
+			if rr.Code != tt.want {
+				t.Errorf("Expected status %d, got %d", tt.want, rr.Code)
+			}
+		})
+	}
+}
+
+func TestServer_Faults(t *testing.T) {
+	srv, client := newFake(t)
+	ctx := context.Background()
+	req := shipping.QuoteRequest{Weight: 1, Zone: "Domestic"}
+
+	// A one-off outage affects only the next call
+	srv.Program(OpRate, Fault{Status: http.StatusServiceUnavailable, Times: 1})
+	var statusErr *StatusError
+	if _, err := client.Rate(ctx, req); !errors.As(err, &statusErr) || statusErr.Status != http.StatusServiceUnavailable {
+		t.Errorf("Expected a 503 StatusError, got %v", err)
+	}
+	if _, err := client.Rate(ctx, req); err != nil {
+		t.Errorf("Expected the fault to clear, got %v", err)
+	}
+	if srv.Calls(OpRate) != 2 {
+		t.Errorf("Expected 2 rate calls, got %d", srv.Calls(OpRate))
+	}
+
+	// Latency longer than the client's deadline surfaces as a timeout
+	srv.Program(OpBook, Fault{Latency: 200 * time.Millisecond})
+	timeout, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.Book(timeout, req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a deadline error, got %v", err)
+	}
+
+	srv.Reset()
+	if _, err := client.Book(ctx, req); err != nil {
+		t.Errorf("Expected success after reset, got %v", err)
+	}
+}
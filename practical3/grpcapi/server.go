@@ -0,0 +1,246 @@
+// server.go
+
+// Package grpcapi serves the shipping calculators over gRPC using the
+// contract in shippingpb.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"shipping"
+	"shipping/shippingpb"
+)
+
+// Server implements shippingpb.QuotingServiceServer on top of a Calculator.
+type Server struct {
+	shippingpb.UnimplementedQuotingServiceServer
+
+	Calculator *shipping.Calculator
+}
+
+// NewServer returns a server pricing with calc.
+func NewServer(calc *shipping.Calculator) *Server {
+	return &Server{Calculator: calc}
+}
+
+// Register adds the quoting service to a gRPC server.
+func (s *Server) Register(g *grpc.Server) {
+	shippingpb.RegisterQuotingServiceServer(g, s)
+}
+
+// Quote implements shippingpb.QuotingServiceServer.
+func (s *Server) Quote(ctx context.Context, in *shippingpb.QuoteRequest) (*shippingpb.QuoteResponse, error) {
+	locale := localeOf(ctx)
+	q, err := s.Calculator.QuoteContext(ctx, fromProtoRequest(in))
+	if err != nil {
+		return nil, toStatus(err, locale)
+	}
+	return &shippingpb.QuoteResponse{Quote: toProtoQuote(shipping.LocalizeQuote(q, locale))}, nil
+}
+
+// RateShop implements shippingpb.QuotingServiceServer.
+func (s *Server) RateShop(ctx context.Context, in *shippingpb.RateShopRequest) (*shippingpb.RateShopResponse, error) {
+	locale := localeOf(ctx)
+	if in.GetRequest() == nil {
+		return nil, invalidArgument(shipping.NewFieldError("request", shipping.MsgRequestRequired), locale)
+	}
+
+	zones := in.GetZones()
+	if len(zones) == 0 {
+		card, err := s.Calculator.RateCard(ctx)
+		if err != nil {
+			return nil, toStatus(err, locale)
+		}
+		zones = zoneNames(card)
+	}
+
+	var available, unavailable []*shippingpb.RateOption
+	var violations []*shipping.FieldError
+	for _, zone := range zones {
+		req := fromProtoRequest(in.GetRequest())
+		req.Zone = zone
+
+		q, err := s.Calculator.QuoteContext(ctx, req)
+		var fe *shipping.FieldError
+		switch {
+		case err == nil:
+			available = append(available, &shippingpb.RateOption{Zone: zone, Quote: toProtoQuote(shipping.LocalizeQuote(q, locale))})
+		case errors.As(err, &fe):
+			unavailable = append(unavailable, &shippingpb.RateOption{Zone: zone, UnavailableReason: shipping.LocalizeError(err, locale)})
+			violations = append(violations, fe)
+		default:
+			return nil, toStatus(err, locale)
+		}
+	}
+	if fe := requestViolation(violations, len(zones)); fe != nil {
+		return nil, invalidArgument(fe, locale)
+	}
+
+	sort.SliceStable(available, func(i, j int) bool {
+		return available[i].Quote.Total < available[j].Quote.Total
+	})
+	return &shippingpb.RateShopResponse{Options: append(available, unavailable...)}, nil
+}
+
+// ListZones implements shippingpb.QuotingServiceServer.
+func (s *Server) ListZones(ctx context.Context, in *shippingpb.ListZonesRequest) (*shippingpb.ListZonesResponse, error) {
+	card, err := s.Calculator.RateCard(ctx)
+	if err != nil {
+		return nil, toStatus(err, localeOf(ctx))
+	}
+	resp := &shippingpb.ListZonesResponse{RateCardVersion: card.Version, MaxWeight: card.MaxWeight}
+	for _, name := range zoneNames(card) {
+		rate := card.Zones[name]
+		resp.Zones = append(resp.Zones, &shippingpb.Zone{Name: name, Base: rate.Base, PerKg: rate.PerKg})
+	}
+	return resp, nil
+}
+
+// requestViolation returns the violation shared by every zone, which means
+// the request itself is invalid (an impossible weight, say) rather than
+// the zones being unavailable. It returns nil if any zone priced or the
+// zones failed for different reasons.
+func requestViolation(violations []*shipping.FieldError, zones int) *shipping.FieldError {
+	if len(violations) == 0 || len(violations) != zones {
+		return nil
+	}
+	first := violations[0]
+	for _, fe := range violations[1:] {
+		if fe.Field != first.Field || fe.Code != first.Code {
+			return nil
+		}
+	}
+	return first
+}
+
+// zoneNames returns the rate card's zones in alphabetical order.
+func zoneNames(card shipping.RateCard) []string {
+	names := make([]string, 0, len(card.Zones))
+	for name := range card.Zones {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// localeOf negotiates the response language from the caller's
+// accept-language metadata.
+func localeOf(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return shipping.Messages.NegotiateLocale(strings.Join(md.Get("accept-language"), ","))
+}
+
+// toStatus maps calculator errors onto gRPC status codes. Validation
+// failures become INVALID_ARGUMENT with a BadRequest field violation.
+func toStatus(err error, locale string) error {
+	var fe *shipping.FieldError
+	switch {
+	case errors.As(err, &fe):
+		return invalidArgument(fe, locale)
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// invalidArgument keeps the status message in English for logs and puts
+// the message in the caller's language in the field violation and a
+// LocalizedMessage detail.
+func invalidArgument(fe *shipping.FieldError, locale string) error {
+	msg := shipping.LocalizeError(fe, locale)
+	st := status.New(codes.InvalidArgument, fe.Message)
+	detailed, err := st.WithDetails(
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: fe.Field, Description: msg}},
+		},
+		&errdetails.LocalizedMessage{Locale: locale, Message: msg},
+	)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+func fromProtoRequest(in *shippingpb.QuoteRequest) shipping.QuoteRequest {
+	req := shipping.QuoteRequest{
+		Weight:       in.GetWeight(),
+		Zone:         in.GetZone(),
+		Package:      in.GetPackage(),
+		Currency:     in.GetCurrency(),
+		CustomerID:   in.GetCustomerId(),
+		Destination:  in.GetDestination(),
+		ReturnOf:     in.GetReturnOf(),
+		Insured:      in.GetInsured(),
+		CarbonOffset: in.GetCarbonOffset(),
+	}
+	if in.ShipDate != nil {
+		req.ShipDate = in.GetShipDate().AsTime()
+	}
+	if d := in.GetDimensions(); d != nil {
+		req.Dimensions = &shipping.Dimensions{Length: d.GetLength(), Width: d.GetWidth(), Height: d.GetHeight()}
+	}
+	if in.CodAmount != nil {
+		req.COD = &shipping.CODOption{Amount: in.GetCodAmount()}
+	}
+	for _, item := range in.GetItems() {
+		req.Items = append(req.Items, shipping.CustomsItem{
+			Description: item.GetDescription(),
+			HSCode:      item.GetHsCode(),
+			Value:       item.GetValue(),
+			Quantity:    int(item.GetQuantity()),
+			Origin:      item.GetOrigin(),
+		})
+	}
+	return req
+}
+
+func toProtoQuote(q shipping.Quote) *shippingpb.Quote {
+	out := &shippingpb.Quote{Currency: q.Currency, Lines: toProtoLines(q.Lines), Total: q.Total}
+	if p := q.Presentment; p != nil {
+		out.Presentment = &shippingpb.Presentment{
+			Currency: p.Currency,
+			Rate:     p.Rate,
+			RateAsOf: timestamppb.New(p.RateAsOf),
+			Lines:    toProtoLines(p.Lines),
+			Total:    p.Total,
+		}
+	}
+	if c := q.Customs; c != nil {
+		out.Customs = &shippingpb.LandedCost{
+			Destination: c.Destination,
+			GoodsValue:  c.GoodsValue,
+			DeMinimis:   c.DeMinimis,
+			Duty:        c.Duty,
+			Vat:         c.VAT,
+			Total:       c.Total,
+		}
+	}
+	if e := q.Emissions; e != nil {
+		out.Emissions = &shippingpb.Emissions{
+			ChargeableWeightKg: e.ChargeableWeight,
+			DistanceKm:         e.Distance,
+			Mode:               string(e.Mode),
+			Co2EKg:             e.CO2e,
+		}
+	}
+	return out
+}
+
+func toProtoLines(lines []shipping.LineItem) []*shippingpb.LineItem {
+	out := make([]*shippingpb.LineItem, 0, len(lines))
+	for _, line := range lines {
+		out = append(out, &shippingpb.LineItem{Code: line.Code, Description: line.Description, Amount: line.Amount})
+	}
+	return out
+}
@@ -0,0 +1,156 @@
+// server_test.go
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"shipping"
+	"shipping/shippingpb"
+)
+
+func newClient(t *testing.T) shippingpb.QuotingServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	g := grpc.NewServer()
+	NewServer(&shipping.Calculator{}).Register(g)
+	go g.Serve(lis)
+	t.Cleanup(g.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return shippingpb.NewQuotingServiceClient(conn)
+}
+
+func TestQuote(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+
+	resp, err := client.Quote(ctx, &shippingpb.QuoteRequest{Weight: 10, Zone: "Domestic"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resp.GetQuote().GetTotal() != 15 || resp.GetQuote().GetCurrency() != "USD" {
+		t.Errorf("Expected 15.00 USD, got %v", resp.GetQuote())
+	}
+	if e := resp.GetQuote().GetEmissions(); e.GetMode() != "road" || e.GetCo2EKg() != 0.33 {
+		t.Errorf("Expected 0.33kg CO2e by road, got %v", e)
+	}
+
+	// Validation failures carry the offending field
+	_, err = client.Quote(ctx, &shippingpb.QuoteRequest{Weight: 10, Zone: "Local"})
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument || st.Message() != "invalid zone: Local" {
+		t.Fatalf("Expected INVALID_ARGUMENT, got %v", err)
+	}
+	var field string
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			field = br.GetFieldViolations()[0].GetField()
+		}
+	}
+	if field != "zone" {
+		t.Errorf("Expected a field violation on zone, got %q", field)
+	}
+}
+
+func TestRateShop(t *testing.T) {
+	client := newClient(t)
+
+	// A tube cannot go International, so that zone is listed as unavailable
+	resp, err := client.RateShop(context.Background(), &shippingpb.RateShopRequest{
+		Request: &shippingpb.QuoteRequest{Weight: 2, Package: "tube"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	options := resp.GetOptions()
+	if len(options) != 3 {
+		t.Fatalf("Expected 3 options, got %d", len(options))
+	}
+	if options[0].GetZone() != "Domestic" || options[1].GetZone() != "Express" {
+		t.Errorf("Expected Domestic then Express, cheapest first, got %v", options)
+	}
+	if options[2].GetZone() != "International" || options[2].GetUnavailableReason() == "" {
+		t.Errorf("Expected International to be unavailable, got %v", options[2])
+	}
+
+	if _, err := client.RateShop(context.Background(), &shippingpb.RateShopRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected INVALID_ARGUMENT for a missing request, got %v", err)
+	}
+
+	// A weight no zone accepts is the request's fault, not the zones'
+	_, err = client.RateShop(context.Background(), &shippingpb.RateShopRequest{
+		Request: &shippingpb.QuoteRequest{Weight: -1},
+	})
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("Expected INVALID_ARGUMENT for an invalid weight, got %v", err)
+	}
+	var field string
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			field = br.GetFieldViolations()[0].GetField()
+		}
+	}
+	if field != "weight" {
+		t.Errorf("Expected a field violation on weight, got %q", field)
+	}
+}
+
+func TestListZones(t *testing.T) {
+	resp, err := newClient(t).ListZones(context.Background(), &shippingpb.ListZonesRequest{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resp.GetRateCardVersion() != "standard" || len(resp.GetZones()) != 3 {
+		t.Errorf("Unexpected zones: %v", resp)
+	}
+	if z := resp.GetZones()[0]; z.GetName() != "Domestic" || z.GetBase() != 5 || z.GetPerKg() != 1 {
+		t.Errorf("Unexpected first zone: %v", z)
+	}
+}
+
+func TestQuoteLocalized(t *testing.T) {
+	client := newClient(t)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "accept-language", "es-MX, en;q=0.5")
+
+	resp, err := client.Quote(ctx, &shippingpb.QuoteRequest{Weight: 10, Zone: "Domestic"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := resp.GetQuote().GetLines()[0].GetDescription(); got != "Gastos de envío" {
+		t.Errorf("Expected a Spanish line description, got %q", got)
+	}
+
+	_, err = client.Quote(ctx, &shippingpb.QuoteRequest{Weight: 10, Zone: "Local"})
+	st := status.Convert(err)
+	if st.Message() != "invalid zone: Local" {
+		t.Errorf("Expected the status message to stay in English, got %q", st.Message())
+	}
+	var localized *errdetails.LocalizedMessage
+	for _, d := range st.Details() {
+		if lm, ok := d.(*errdetails.LocalizedMessage); ok {
+			localized = lm
+		}
+	}
+	if localized == nil || localized.GetLocale() != "es" || localized.GetMessage() != "zona no válida: Local" {
+		t.Errorf("Expected a Spanish localized message, got %v", localized)
+	}
+}
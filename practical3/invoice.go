@@ -0,0 +1,286 @@
+// invoice.go
+package shipping
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// BilledShipment is a priced shipment waiting to be invoiced.
+type BilledShipment struct {
+	CustomerID     string    `json:"customer_id"`
+	TrackingNumber string    `json:"tracking_number"`
+	ShippedAt      time.Time `json:"shipped_at"`
+	Quote          Quote     `json:"quote"`
+}
+
+// BillingPeriod is the half-open interval [Start, End) an invoice covers.
+type BillingPeriod struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// MonthlyPeriod returns the billing period for a calendar month in UTC.
+func MonthlyPeriod(year int, month time.Month) BillingPeriod {
+	start := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	return BillingPeriod{Start: start, End: start.AddDate(0, 1, 0)}
+}
+
+// Contains reports whether t falls inside the period.
+func (p BillingPeriod) Contains(t time.Time) bool {
+	return !t.Before(p.Start) && t.Before(p.End)
+}
+
+// VolumeRebate gives customers with at least MinShipments in a period a
+// Percent discount (0.05 = 5%) on that period's invoice.
+type VolumeRebate struct {
+	MinShipments int     `json:"min_shipments"`
+	Percent      float64 `json:"percent"`
+}
+
+// Invoice kinds.
+const (
+	KindInvoice    = "invoice"
+	KindCreditNote = "credit_note"
+)
+
+// InvoiceLine is one charge on an invoice, traced back to its shipment.
+type InvoiceLine struct {
+	TrackingNumber string    `json:"tracking_number,omitempty"`
+	ShippedAt      time.Time `json:"shipped_at,omitzero"`
+	Code           string    `json:"code"`
+	Description    string    `json:"description"`
+	Amount         float64   `json:"amount"`
+}
+
+// Invoice is a billing document for one customer and period. Credit notes
+// use the same shape with negative amounts and a reference to the invoice
+// they correct.
+type Invoice struct {
+	Number        string        `json:"number"`
+	Kind          string        `json:"kind"`
+	References    string        `json:"references,omitempty"`
+	CustomerID    string        `json:"customer_id"`
+	Period        BillingPeriod `json:"period"`
+	Currency      string        `json:"currency"`
+	Shipments     int           `json:"shipments"`
+	Lines         []InvoiceLine `json:"lines"`
+	Subtotal      float64       `json:"subtotal"`
+	RebatePercent float64       `json:"rebate_percent"`
+	Rebate        float64       `json:"rebate"`
+	Total         float64       `json:"total"`
+	Reason        string        `json:"reason,omitempty"`
+}
+
+// Invoicer turns priced shipments into numbered invoices and credit notes.
+// It is safe for concurrent use.
+type Invoicer struct {
+	Prefix  string
+	Rebates []VolumeRebate
+
+	mu   sync.Mutex
+	next int
+	// credited maps invoice numbers to the credit note number issued for
+	// each refunded tracking number.
+	credited map[string]map[string]string
+}
+
+// Build produces one invoice per customer for the shipments shipped within
+// period. Shipments outside the period are ignored. Invoices are returned
+// ordered by customer ID. Numbers are only assigned once every invoice has
+// been built, so a failed run leaves no gap in the sequence.
+func (inv *Invoicer) Build(period BillingPeriod, shipments []BilledShipment) ([]Invoice, error) {
+	byCustomer := make(map[string][]BilledShipment)
+	for _, s := range shipments {
+		if !period.Contains(s.ShippedAt) {
+			continue
+		}
+		if s.CustomerID == "" {
+			return nil, fmt.Errorf("shipment %s has no customer", s.TrackingNumber)
+		}
+		byCustomer[s.CustomerID] = append(byCustomer[s.CustomerID], s)
+	}
+
+	customers := make([]string, 0, len(byCustomer))
+	for id := range byCustomer {
+		customers = append(customers, id)
+	}
+	sort.Strings(customers)
+
+	var invoices []Invoice
+	for _, id := range customers {
+		invoice, err := inv.build(id, period, byCustomer[id])
+		if err != nil {
+			return nil, err
+		}
+		invoices = append(invoices, invoice)
+	}
+
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	for i := range invoices {
+		invoices[i].Number = inv.nextNumber()
+	}
+	return invoices, nil
+}
+
+// build creates the unnumbered invoice for a single customer's shipments.
+func (inv *Invoicer) build(customerID string, period BillingPeriod, shipments []BilledShipment) (Invoice, error) {
+	sort.Slice(shipments, func(i, j int) bool {
+		return shipments[i].ShippedAt.Before(shipments[j].ShippedAt)
+	})
+
+	invoice := Invoice{
+		Kind:       KindInvoice,
+		CustomerID: customerID,
+		Period:     period,
+		Currency:   shipments[0].Quote.Currency,
+		Shipments:  len(shipments),
+	}
+	for _, s := range shipments {
+		if s.Quote.Currency != invoice.Currency {
+			return Invoice{}, fmt.Errorf("customer %s has shipments in both %s and %s", customerID, invoice.Currency, s.Quote.Currency)
+		}
+		for _, line := range s.Quote.Lines {
+			invoice.Lines = append(invoice.Lines, InvoiceLine{
+				TrackingNumber: s.TrackingNumber,
+				ShippedAt:      s.ShippedAt,
+				Code:           line.Code,
+				Description:    line.Description,
+				Amount:         line.Amount,
+			})
+			invoice.Subtotal = roundCents(invoice.Subtotal + line.Amount)
+		}
+	}
+
+	invoice.RebatePercent = inv.rebateFor(len(shipments))
+	invoice.Rebate = roundCents(invoice.Subtotal * invoice.RebatePercent)
+	invoice.Total = roundCents(invoice.Subtotal - invoice.Rebate)
+	return invoice, nil
+}
+
+// rebateFor returns the best rebate a customer with count shipments qualifies for.
+func (inv *Invoicer) rebateFor(count int) float64 {
+	best := 0.0
+	for _, r := range inv.Rebates {
+		if count >= r.MinShipments && r.Percent > best {
+			best = r.Percent
+		}
+	}
+	return best
+}
+
+// CreditNote refunds the listed shipments from an issued invoice. The
+// invoice's volume rebate is honoured, so the customer gets back what they
+// actually paid for those shipments. Each shipment on an invoice can only
+// be credited once.
+func (inv *Invoicer) CreditNote(original Invoice, trackingNumbers []string, reason string) (Invoice, error) {
+	if original.Kind != KindInvoice {
+		return Invoice{}, errors.New("credit notes can only be raised against invoices")
+	}
+	if len(trackingNumbers) == 0 {
+		return Invoice{}, errors.New("credit note needs at least one shipment")
+	}
+
+	refund := make(map[string]bool, len(trackingNumbers))
+	for _, tn := range trackingNumbers {
+		refund[tn] = false
+	}
+
+	note := Invoice{
+		Kind:          KindCreditNote,
+		References:    original.Number,
+		CustomerID:    original.CustomerID,
+		Period:        original.Period,
+		Currency:      original.Currency,
+		RebatePercent: original.RebatePercent,
+		Reason:        reason,
+	}
+	for _, line := range original.Lines {
+		if _, ok := refund[line.TrackingNumber]; !ok {
+			continue
+		}
+		if !refund[line.TrackingNumber] {
+			refund[line.TrackingNumber] = true
+			note.Shipments++
+		}
+		line.Amount = -line.Amount
+		note.Lines = append(note.Lines, line)
+		note.Subtotal = roundCents(note.Subtotal + line.Amount)
+	}
+	for tn, found := range refund {
+		if !found {
+			return Invoice{}, fmt.Errorf("shipment %s is not on invoice %s", tn, original.Number)
+		}
+	}
+
+	note.Rebate = roundCents(note.Subtotal * note.RebatePercent)
+	note.Total = roundCents(note.Subtotal - note.Rebate)
+
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	credited := inv.credited[original.Number]
+	for _, tn := range trackingNumbers {
+		if number, ok := credited[tn]; ok {
+			return Invoice{}, fmt.Errorf("shipment %s on invoice %s was already credited by %s", tn, original.Number, number)
+		}
+	}
+	note.Number = inv.nextNumber()
+	if credited == nil {
+		credited = make(map[string]string)
+		if inv.credited == nil {
+			inv.credited = make(map[string]map[string]string)
+		}
+		inv.credited[original.Number] = credited
+	}
+	for tn := range refund {
+		credited[tn] = note.Number
+	}
+	return note, nil
+}
+
+// nextNumber returns the next document number. inv.mu must be held.
+func (inv *Invoicer) nextNumber() string {
+	inv.next++
+	prefix := inv.Prefix
+	if prefix == "" {
+		prefix = "INV"
+	}
+	return fmt.Sprintf("%s-%06d", prefix, inv.next)
+}
+
+// WriteJSON writes the invoice as an indented JSON document.
+func (i Invoice) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(i)
+}
+
+// WriteCSV writes the invoice as CSV: one row per line, followed by
+// subtotal, rebate and total rows.
+func (i Invoice) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	money := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+
+	cw.Write([]string{"invoice", "kind", "customer_id", "tracking_number", "shipped_at", "code", "description", "amount", "currency"})
+	for _, line := range i.Lines {
+		cw.Write([]string{
+			i.Number, i.Kind, i.CustomerID, line.TrackingNumber,
+			line.ShippedAt.Format(time.RFC3339), line.Code, line.Description,
+			money(line.Amount), i.Currency,
+		})
+	}
+	cw.Write([]string{i.Number, i.Kind, i.CustomerID, "", "", "subtotal", "Subtotal", money(i.Subtotal), i.Currency})
+	cw.Write([]string{i.Number, i.Kind, i.CustomerID, "", "", "rebate", "Volume rebate", money(-i.Rebate), i.Currency})
+	cw.Write([]string{i.Number, i.Kind, i.CustomerID, "", "", "total", "Total", money(i.Total), i.Currency})
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,153 @@
+// invoice_test.go
+package shipping
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func billed(customer, tracking string, day int, total float64) BilledShipment {
+	return BilledShipment{
+		CustomerID:     customer,
+		TrackingNumber: tracking,
+		ShippedAt:      time.Date(2025, time.November, day, 10, 0, 0, 0, time.UTC),
+		Quote: Quote{
+			Currency: "USD",
+			Lines:    []LineItem{{Code: "shipping", Description: "Shipping fee", Amount: total}},
+			Total:    total,
+		},
+	}
+}
+
+func TestInvoicer_Build(t *testing.T) {
+	inv := &Invoicer{Rebates: []VolumeRebate{{MinShipments: 3, Percent: 0.05}, {MinShipments: 10, Percent: 0.10}}}
+
+	shipments := []BilledShipment{
+		billed("acme", "SH000000015BT", 3, 15),
+		billed("acme", "SH000000029BT", 1, 45),
+		billed("acme", "SH000000032BT", 20, 40),
+		billed("bob", "SH000000046BT", 5, 7),
+		// Outside the billing period
+		billed("bob", "SH000000050BT", 5, 7),
+	}
+	shipments[4].ShippedAt = time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC)
+
+	invoices, err := inv.Build(MonthlyPeriod(2025, time.November), shipments)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(invoices) != 2 {
+		t.Fatalf("Expected 2 invoices, got %d", len(invoices))
+	}
+
+	acme := invoices[0]
+	if acme.CustomerID != "acme" || acme.Shipments != 3 {
+		t.Fatalf("Expected acme's invoice with 3 shipments first, got %+v", acme)
+	}
+	// Lines are ordered by ship date
+	if acme.Lines[0].TrackingNumber != "SH000000029BT" {
+		t.Errorf("Expected the earliest shipment first, got %s", acme.Lines[0].TrackingNumber)
+	}
+	// 100.00 subtotal with a 5% rebate
+	if acme.Subtotal != 100 || acme.Rebate != 5 || acme.Total != 95 {
+		t.Errorf("Expected 100 - 5 = 95, got %.2f - %.2f = %.2f", acme.Subtotal, acme.Rebate, acme.Total)
+	}
+
+	bob := invoices[1]
+	if bob.Shipments != 1 || bob.Rebate != 0 || bob.Total != 7 {
+		t.Errorf("Unexpected invoice for bob: %+v", bob)
+	}
+	if acme.Number == bob.Number {
+		t.Error("Expected invoice numbers to be unique")
+	}
+
+	// Mixing currencies for one customer is an error
+	mixed := billed("acme", "SH000000063BT", 2, 10)
+	mixed.Quote.Currency = "EUR"
+	if _, err := inv.Build(MonthlyPeriod(2025, time.November), append(shipments, mixed)); err == nil {
+		t.Error("Expected an error for mixed currencies")
+	}
+
+	// The failed run must not use up any numbers
+	next, err := inv.Build(MonthlyPeriod(2025, time.November), shipments[3:4])
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if acme.Number != "INV-000001" || bob.Number != "INV-000002" || next[0].Number != "INV-000003" {
+		t.Errorf("Expected INV-000001 to INV-000003 with no gaps, got %s, %s, %s", acme.Number, bob.Number, next[0].Number)
+	}
+}
+
+func TestInvoicer_CreditNote(t *testing.T) {
+	inv := &Invoicer{Rebates: []VolumeRebate{{MinShipments: 2, Percent: 0.10}}}
+	invoices, err := inv.Build(MonthlyPeriod(2025, time.November), []BilledShipment{
+		billed("acme", "SH000000015BT", 1, 20),
+		billed("acme", "SH000000029BT", 2, 30),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	note, err := inv.CreditNote(invoices[0], []string{"SH000000029BT"}, "lost in transit")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if note.Kind != KindCreditNote || note.References != invoices[0].Number {
+		t.Errorf("Unexpected credit note header: %+v", note)
+	}
+	// 30.00 refunded less the 10% rebate the customer already received
+	if note.Subtotal != -30 || note.Total != -27 {
+		t.Errorf("Expected a -27.00 credit, got %.2f", note.Total)
+	}
+
+	if _, err := inv.CreditNote(invoices[0], []string{"SH999999999BT"}, ""); err == nil {
+		t.Error("Expected an error for a shipment not on the invoice")
+	}
+	if _, err := inv.CreditNote(note, []string{"SH000000029BT"}, ""); err == nil {
+		t.Error("Expected an error crediting a credit note")
+	}
+
+	// A shipment can only be credited once, even alongside a new one
+	if _, err := inv.CreditNote(invoices[0], []string{"SH000000029BT"}, "lost in transit"); err == nil {
+		t.Error("Expected an error crediting the same shipment twice")
+	}
+	if _, err := inv.CreditNote(invoices[0], []string{"SH000000015BT", "SH000000029BT"}, ""); err == nil {
+		t.Error("Expected an error when any shipment was already credited")
+	}
+	if _, err := inv.CreditNote(invoices[0], []string{"SH000000015BT"}, "damaged"); err != nil {
+		t.Errorf("Expected the remaining shipment to be creditable, got %v", err)
+	}
+}
+
+func TestInvoice_Export(t *testing.T) {
+	inv := &Invoicer{Prefix: "NOV"}
+	invoices, _ := inv.Build(MonthlyPeriod(2025, time.November), []BilledShipment{billed("acme", "SH000000015BT", 1, 20)})
+
+	var buf bytes.Buffer
+	if err := invoices[0].WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var decoded Invoice
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if decoded.Number != "NOV-000001" || decoded.Total != 20 {
+		t.Errorf("Unexpected decoded invoice: %+v", decoded)
+	}
+
+	buf.Reset()
+	if err := invoices[0].WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Invalid CSV: %v", err)
+	}
+	// Header, one line, subtotal, rebate and total
+	if len(rows) != 5 || rows[1][3] != "SH000000015BT" || rows[4][7] != "20.00" {
+		t.Errorf("Unexpected CSV rows: %v", rows)
+	}
+}
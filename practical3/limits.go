@@ -0,0 +1,199 @@
+// limits.go
+package shipping
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Services a request can be priced as, used to pick service-level fee limits.
+const (
+	ServiceStandard = "standard"
+	ServiceCOD      = "cod"
+	ServiceReturn   = "return"
+)
+
+// Service returns which service the request is for.
+func (req QuoteRequest) Service() string {
+	switch {
+	case req.ReturnOf != "":
+		return ServiceReturn
+	case req.COD != nil:
+		return ServiceCOD
+	default:
+		return ServiceStandard
+	}
+}
+
+// FeeLimit bounds a quote total. A zero Min or Max means no bound.
+type FeeLimit struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// PriceEnding adjusts a total to a preferred price point, e.g. 12.99.
+type PriceEnding func(total float64) float64
+
+// EndingIn rounds up to the next price ending in the given cents,
+// e.g. EndingIn(0.99) turns 12.10 into 12.99 and 12.99 stays 12.99.
+func EndingIn(cents float64) PriceEnding {
+	return func(total float64) float64 {
+		whole := math.Floor(total)
+		candidate := whole + cents
+		if candidate < total-0.000001 {
+			candidate += 1
+		}
+		return candidate
+	}
+}
+
+// NearestStep rounds to the nearest multiple of step, e.g. 0.05.
+func NearestStep(step float64) PriceEnding {
+	return func(total float64) float64 {
+		return math.Round(total/step) * step
+	}
+}
+
+// UpToStep rounds up to the next multiple of step.
+func UpToStep(step float64) PriceEnding {
+	return func(total float64) float64 {
+		return math.Ceil(total/step-0.000001) * step
+	}
+}
+
+// PricePolicy holds the minimum and maximum fees per zone and per service
+// and an optional price ending. When both a zone and a service limit apply
+// the stricter bound wins. Limits are in the base currency; the ending is
+// applied to the amount the customer pays, after any conversion.
+type PricePolicy struct {
+	Zones    map[string]FeeLimit
+	Services map[string]FeeLimit
+	Ending   PriceEnding
+}
+
+// NewPricePolicy returns a policy with the given limits and ending, or an
+// error if any zone and service combine into a minimum above the maximum.
+func NewPricePolicy(zones, services map[string]FeeLimit, ending PriceEnding) (*PricePolicy, error) {
+	p := &PricePolicy{Zones: zones, Services: services, Ending: ending}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Validate checks every zone and service limit, alone and combined, for a
+// minimum above the maximum. Policies built with NewPricePolicy are
+// already valid; in one that is not, the maximum wins.
+func (p *PricePolicy) Validate() error {
+	zones := append([]string{""}, sortedLimitKeys(p.Zones)...)
+	services := append([]string{""}, sortedLimitKeys(p.Services)...)
+	for _, zone := range zones {
+		for _, service := range services {
+			if limit := p.limitFor(zone, service); limit.Max > 0 && limit.Min > limit.Max {
+				return fmt.Errorf("price policy: minimum fee %.2f is above the maximum fee %.2f for zone %q and service %q", limit.Min, limit.Max, zone, service)
+			}
+		}
+	}
+	return nil
+}
+
+func sortedLimitKeys(m map[string]FeeLimit) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// limitFor combines the zone and service limits.
+func (p *PricePolicy) limitFor(zone, service string) FeeLimit {
+	var limit FeeLimit
+	for _, l := range []FeeLimit{p.Zones[zone], p.Services[service]} {
+		if l.Min > limit.Min {
+			limit.Min = l.Min
+		}
+		if l.Max > 0 && (limit.Max == 0 || l.Max < limit.Max) {
+			limit.Max = l.Max
+		}
+	}
+	return limit
+}
+
+// limitStage applies minimum and maximum fees and the price ending, adding
+// a line for each adjustment so the breakdown still adds up. A quote that
+// will be converted gets its ending from currencyStage instead.
+func limitStage(pc *PricingContext) error {
+	p := pc.Calculator.Limits
+	if p == nil {
+		return nil
+	}
+	q := pc.Quote
+
+	limit := p.limitFor(pc.Request.Zone, pc.Request.Service())
+	if limit.Min > 0 && q.Total < limit.Min {
+		q.AddLine(newLine("minimum_charge", roundCents(limit.Min-q.Total)))
+	}
+	if limit.Max > 0 && q.Total > limit.Max {
+		q.AddLine(newLine("maximum_charge", roundCents(limit.Max-q.Total)))
+	}
+
+	if p.Ending != nil && !pc.converting() {
+		if adjustment := roundCents(endWithin(p.Ending, q.Total, limit) - q.Total); adjustment != 0 {
+			q.AddLine(newLine("price_ending", adjustment))
+		}
+	}
+
+	q.Total = roundCents(q.Total)
+	return nil
+}
+
+// endPresentment applies the price ending to the converted total, keeping
+// it within the zone and service limits converted at the same rate.
+func (p *PricePolicy) endPresentment(pres *Presentment, cur Currency, zone, service string) {
+	if p.Ending == nil {
+		return
+	}
+	limit := p.limitFor(zone, service)
+	limit.Min *= pres.Rate
+	limit.Max *= pres.Rate
+	if adjustment := cur.Round(endWithin(p.Ending, pres.Total, limit) - pres.Total); adjustment != 0 {
+		pres.Lines = append(pres.Lines, newLine("price_ending", adjustment))
+		pres.Total = cur.Round(pres.Total + adjustment)
+	}
+}
+
+// endingSearchCents is how far past a bound endWithin looks for an allowed
+// price ending. Endings repeat well within 100.00.
+const endingSearchCents = 100 * 100
+
+// endWithin applies ending to total without leaving limit. When the ending
+// would cross a bound it takes the nearest ending on the allowed side of
+// that bound, and when there is none it keeps total unchanged.
+func endWithin(ending PriceEnding, total float64, limit FeeLimit) float64 {
+	ended := roundCents(ending(total))
+	switch {
+	case limit.Max > 0 && ended > limit.Max:
+		// Endings only grow with the total, so search down from the cap
+		n := sort.Search(endingSearchCents+1, func(n int) bool {
+			return roundCents(ending(limit.Max-float64(n)/100)) <= limit.Max
+		})
+		if n > endingSearchCents {
+			return total
+		}
+		ended = roundCents(ending(limit.Max - float64(n)/100))
+	case ended < limit.Min:
+		n := sort.Search(endingSearchCents+1, func(n int) bool {
+			return roundCents(ending(limit.Min+float64(n)/100)) >= limit.Min
+		})
+		if n > endingSearchCents {
+			return total
+		}
+		ended = roundCents(ending(limit.Min + float64(n)/100))
+	}
+	if ended < limit.Min || (limit.Max > 0 && ended > limit.Max) {
+		return total
+	}
+	return ended
+}
@@ -0,0 +1,165 @@
+// limits_test.go
+package shipping
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestPriceEndings(t *testing.T) {
+	testCases := []struct {
+		name     string
+		ending   PriceEnding
+		total    float64
+		expected float64
+	}{
+		{"Up to .99", EndingIn(0.99), 12.10, 12.99},
+		{"Already .99", EndingIn(0.99), 12.99, 12.99},
+		{"Whole number to .99", EndingIn(0.99), 13, 13.99},
+		{"Nearest 0.05 down", NearestStep(0.05), 12.12, 12.10},
+		{"Nearest 0.05 up", NearestStep(0.05), 12.13, 12.15},
+		{"Up to 0.50", UpToStep(0.50), 12.01, 12.50},
+		{"Exact 0.50", UpToStep(0.50), 12.50, 12.50},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.ending(tc.total); math.Abs(got-tc.expected) > 0.0001 {
+				t.Errorf("Expected %.2f, got %.2f", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestCalculator_QuoteLimits(t *testing.T) {
+	c := Calculator{Limits: &PricePolicy{
+		Zones: map[string]FeeLimit{
+			"Domestic": {Min: 7.50},
+			"Express":  {Max: 100},
+		},
+		Services: map[string]FeeLimit{
+			ServiceCOD: {Min: 12},
+		},
+		Ending: EndingIn(0.99),
+	}}
+
+	testCases := []struct {
+		name     string
+		req      QuoteRequest
+		expected float64
+		codes    []string
+	}{
+		// 5.50 is lifted to 7.50, then ends in .99
+		{"Minimum applies", QuoteRequest{Weight: 0.5, Zone: "Domestic"}, 7.99, []string{"shipping", "minimum_charge", "price_ending"}},
+		// 30 + 50 * 5 = 280 is capped at 100.00; 100.99 would break the cap so it ends at 99.99
+		{"Maximum applies", QuoteRequest{Weight: 50, Zone: "Express"}, 99.99, []string{"shipping", "maximum_charge", "price_ending"}},
+		// The COD service minimum is stricter than the zone minimum: 5.50 + 2.00 COD fee -> 12.00
+		{"Service minimum", QuoteRequest{Weight: 0.5, Zone: "Domestic", COD: &CODOption{Amount: 10}}, 12.99, []string{"shipping", "cod_fee", "minimum_charge", "price_ending"}},
+		// 45.00 needs only the ending
+		{"Ending only", QuoteRequest{Weight: 10, Zone: "International"}, 45.99, []string{"shipping", "price_ending"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := c.Quote(tc.req)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if math.Abs(q.Total-tc.expected) > 0.0001 {
+				t.Errorf("Expected total %.2f, got %.2f", tc.expected, q.Total)
+			}
+			if len(q.Lines) != len(tc.codes) {
+				t.Fatalf("Expected lines %v, got %+v", tc.codes, q.Lines)
+			}
+			sum := 0.0
+			for i, line := range q.Lines {
+				if line.Code != tc.codes[i] {
+					t.Errorf("Line %d: expected %s, got %s", i, tc.codes[i], line.Code)
+				}
+				sum += line.Amount
+			}
+			// The breakdown must still add up to the total
+			if math.Abs(sum-q.Total) > 0.0001 {
+				t.Errorf("Lines add up to %.2f, total is %.2f", sum, q.Total)
+			}
+		})
+	}
+
+	// Contradictory limits are rejected when the policy is built
+	_, err := NewPricePolicy(
+		map[string]FeeLimit{"Domestic": {Max: 5}},
+		map[string]FeeLimit{ServiceStandard: {Min: 10}},
+		nil,
+	)
+	if err == nil {
+		t.Error("Expected an error when the minimum exceeds the maximum")
+	}
+	if _, err := NewPricePolicy(map[string]FeeLimit{"Express": {Min: 10, Max: 5}}, nil, nil); err == nil {
+		t.Error("Expected an error for a zone whose minimum exceeds its maximum")
+	}
+}
+
+func TestCalculator_QuoteLimitsEndConvertedPrice(t *testing.T) {
+	rates, err := LoadExchangeRates("USD", strings.NewReader(sampleRates))
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy, err := NewPricePolicy(nil, nil, EndingIn(0.99))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := Calculator{Rates: rates, Limits: policy}
+
+	// 45.00 USD is 41.40 EUR, which ends at 41.99 EUR; the USD total is left alone
+	q, err := c.Quote(QuoteRequest{Weight: 10, Zone: "International", Currency: "EUR"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if q.Total != 45 {
+		t.Errorf("Expected a base total of 45.00, got %+v", q)
+	}
+	p := q.Presentment
+	if p == nil || p.Total != 41.99 || p.Lines[len(p.Lines)-1].Code != "price_ending" {
+		t.Fatalf("Expected a 41.99 EUR presentment ending in .99, got %+v", p)
+	}
+	sum := 0.0
+	for _, line := range p.Lines {
+		sum += line.Amount
+	}
+	if math.Abs(sum-p.Total) > 0.0001 {
+		t.Errorf("Lines add up to %.2f, total is %.2f", sum, p.Total)
+	}
+}
+
+func TestCalculator_QuoteLimitsKeepEndingWithinBounds(t *testing.T) {
+	testCases := []struct {
+		name     string
+		policy   PricePolicy
+		req      QuoteRequest
+		expected float64
+	}{
+		// 30 + 10 * 5 = 80 is capped at 50.00; 50.99 would break the cap
+		{"Ending below cap", PricePolicy{Zones: map[string]FeeLimit{"Express": {Max: 50}}, Ending: EndingIn(0.99)},
+			QuoteRequest{Weight: 10, Zone: "Express"}, 49.99},
+		// 5.50 is lifted to 7.52; rounding to 7.50 would break the minimum
+		{"Ending above minimum", PricePolicy{Zones: map[string]FeeLimit{"Domestic": {Min: 7.52}}, Ending: NearestStep(0.05)},
+			QuoteRequest{Weight: 0.5, Zone: "Domestic"}, 7.55},
+		// No .99 ending fits between 7.00 and 7.50, so the ending is dropped
+		{"No ending fits", PricePolicy{Zones: map[string]FeeLimit{"Domestic": {Min: 7, Max: 7.50}}, Ending: EndingIn(0.99)},
+			QuoteRequest{Weight: 0.5, Zone: "Domestic"}, 7},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := Calculator{Limits: &tc.policy}
+			q, err := c.Quote(tc.req)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if math.Abs(q.Total-tc.expected) > 0.0001 {
+				t.Errorf("Expected total %.2f, got %.2f", tc.expected, q.Total)
+			}
+		})
+	}
+}
@@ -0,0 +1,415 @@
+// messages.go
+package shipping
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLocale is used when nothing the client accepts is available.
+const DefaultLocale = "en"
+
+// Message codes for validation errors. They are stable and safe to match on.
+const (
+	MsgInvalidWeight          = "invalid_weight"
+	MsgInvalidZone            = "invalid_zone"
+	MsgPackageUnknown         = "package_unknown"
+	MsgPackageTooHeavy        = "package_too_heavy"
+	MsgPackageBadDimensions   = "package_invalid_dimensions"
+	MsgPackageTooLarge        = "package_too_large"
+	MsgPackageZone            = "package_zone_not_allowed"
+	MsgCODInternational       = "cod_international"
+	MsgCODAmountInvalid       = "cod_amount_invalid"
+	MsgCODAmountLimit         = "cod_amount_limit"
+	MsgReturnInvalidTracking  = "return_invalid_tracking"
+	MsgReturnUnavailable      = "return_unavailable"
+	MsgReturnNotFound         = "return_not_found"
+	MsgReturnNotReturnable    = "return_not_returnable"
+	MsgReturnZoneMismatch     = "return_zone_mismatch"
+	MsgReturnCOD              = "return_cod"
+	MsgCustomsNoRules         = "customs_no_rules"
+	MsgCustomsNoItems         = "customs_no_items"
+	MsgCustomsInvalidHSCode   = "customs_invalid_hs_code"
+	MsgCustomsInvalidValue    = "customs_invalid_value"
+	MsgCustomsInvalidQuantity = "customs_invalid_quantity"
+	MsgCustomsInvalidOrigin   = "customs_invalid_origin"
+	MsgCurrencyNoRates        = "currency_no_rates"
+	MsgCurrencyUnsupported    = "currency_unsupported"
+	MsgCurrencyNoRate         = "currency_no_rate"
+	MsgOffsetNoRoute          = "offset_no_route"
+	MsgRequestRequired        = "request_required"
+
+	MsgRateCardVersionRequired   = "ratecard_version_required"
+	MsgRateCardAuthorRequired    = "ratecard_author_required"
+	MsgRateCardEditorRequired    = "ratecard_editor_required"
+	MsgRateCardApproverRequired  = "ratecard_approver_required"
+	MsgRateCardRollbackBy        = "ratecard_rollback_by"
+	MsgRateCardEffectivePast     = "ratecard_effective_past"
+	MsgRateCardNoZones           = "ratecard_no_zones"
+	MsgRateCardMaxWeight         = "ratecard_max_weight"
+	MsgRateCardNegativeRate      = "ratecard_negative_rate"
+	MsgRateCardNegativeSurcharge = "ratecard_negative_surcharge"
+
+	MsgWeightUnit      = "weight_unit"
+	MsgWeightAmbiguous = "weight_ambiguous"
+	MsgZoneAmbiguous   = "zone_ambiguous"
+	MsgInsuredInvalid  = "insured_invalid"
+)
+
+// Catalogue maps a locale to its messages, keyed by message code. Line item
+// descriptions are keyed "line." plus the line item code. Messages are fmt
+// formats using explicit argument indexes, so translations can reorder them.
+type Catalogue map[string]map[string]string
+
+// Messages holds every shipping message. English is complete; other
+// locales fall back to English for anything they lack.
+var Messages = Catalogue{
+	"en": {
+		MsgInvalidWeight:             "invalid weight",
+		MsgInvalidZone:               "invalid zone: %[1]s",
+		MsgPackageUnknown:            "invalid package type: %[1]s",
+		MsgPackageTooHeavy:           "%[1]s cannot weigh more than %[2]gkg",
+		MsgPackageBadDimensions:      "invalid dimensions for %[1]s",
+		MsgPackageTooLarge:           "%[1]s cannot exceed %[2]gx%[3]gx%[4]gcm",
+		MsgPackageZone:               "%[1]s cannot be sent %[2]s",
+		MsgCODInternational:          "cash on delivery is not available for International parcels",
+		MsgCODAmountInvalid:          "cash on delivery amount must be positive",
+		MsgCODAmountLimit:            "cash on delivery amount exceeds the %.2[1]f limit",
+		MsgReturnInvalidTracking:     "invalid tracking number: %[1]s",
+		MsgReturnUnavailable:         "return labels are not available",
+		MsgReturnNotFound:            "return label for %[1]s: shipment not found",
+		MsgReturnNotReturnable:       "shipment %[1]s cannot be returned while %[2]s",
+		MsgReturnZoneMismatch:        "return must use the original zone %[1]s",
+		MsgReturnCOD:                 "return labels cannot be sent cash on delivery",
+		MsgCustomsNoRules:            "no customs rules for destination: %[1]s",
+		MsgCustomsNoItems:            "customs declaration has no items",
+		MsgCustomsInvalidHSCode:      "customs item %[1]d: invalid HS code: %[2]s",
+		MsgCustomsInvalidValue:       "customs item %[1]d: declared value must be positive",
+		MsgCustomsInvalidQuantity:    "customs item %[1]d: quantity must be positive",
+		MsgCustomsInvalidOrigin:      "customs item %[1]d: invalid origin country: %[2]s",
+		MsgCurrencyNoRates:           "no exchange rates loaded for %[1]s",
+		MsgCurrencyUnsupported:       "unsupported currency: %[1]s",
+		MsgCurrencyNoRate:            "no exchange rate from %[1]s to %[2]s",
+		MsgOffsetNoRoute:             "carbon offsets are not available for zone %[1]s",
+		MsgRequestRequired:           "request is required",
+		MsgRateCardVersionRequired:   "rate card version is required",
+		MsgRateCardAuthorRequired:    "author is required",
+		MsgRateCardEditorRequired:    "editor is required",
+		MsgRateCardApproverRequired:  "approver is required",
+		MsgRateCardRollbackBy:        "the user rolling back is required",
+		MsgRateCardEffectivePast:     "effective date must not be in the past",
+		MsgRateCardNoZones:           "rate card has no zones",
+		MsgRateCardMaxWeight:         "rate card max weight must be positive",
+		MsgRateCardNegativeRate:      "rate card zone %[1]s has a negative rate",
+		MsgRateCardNegativeSurcharge: "rate card surcharges must not be negative",
+		MsgWeightUnit:                "unknown weight unit: %[1]s",
+		MsgWeightAmbiguous:           "ambiguous weight %[1]s: could be thousands or a decimal",
+		MsgZoneAmbiguous:             "ambiguous zone %[1]s: could be %[2]s",
+		MsgInsuredInvalid:            "invalid insured value: %[1]s",
+
+		"line.shipping":        "Shipping fee",
+		"line.flat_rate":       "Flat rate (%[1]s)",
+		"line.heavy_surcharge": "Heavy parcel surcharge",
+		"line.insurance":       "Insurance",
+		"line.peak_surcharge":  "Peak surcharge (%[1]s)",
+		"line.cod_fee":         "Cash on delivery fee",
+		"line.return_discount": "Return label discount (%[1]s)",
+		"line.volume_discount": "Volume discount (%[1]s+ shipments)",
+		"line.minimum_charge":  "Minimum charge adjustment",
+		"line.maximum_charge":  "Maximum charge adjustment",
+		"line.price_ending":    "Price rounding",
+		"line.carbon_offset":   "Carbon offset",
+	},
+	"es": {
+		MsgInvalidWeight:             "peso no válido",
+		MsgInvalidZone:               "zona no válida: %[1]s",
+		MsgPackageUnknown:            "tipo de paquete no válido: %[1]s",
+		MsgPackageTooHeavy:           "%[1]s no puede pesar más de %[2]gkg",
+		MsgPackageBadDimensions:      "dimensiones no válidas para %[1]s",
+		MsgPackageTooLarge:           "%[1]s no puede superar %[2]gx%[3]gx%[4]gcm",
+		MsgPackageZone:               "%[1]s no se puede enviar a la zona %[2]s",
+		MsgCODInternational:          "el pago contra reembolso no está disponible para envíos internacionales",
+		MsgCODAmountInvalid:          "el importe contra reembolso debe ser positivo",
+		MsgCODAmountLimit:            "el importe contra reembolso supera el límite de %.2[1]f",
+		MsgReturnInvalidTracking:     "número de seguimiento no válido: %[1]s",
+		MsgReturnUnavailable:         "las etiquetas de devolución no están disponibles",
+		MsgReturnNotFound:            "etiqueta de devolución para %[1]s: envío no encontrado",
+		MsgReturnNotReturnable:       "el envío %[1]s no se puede devolver mientras su estado sea %[2]s",
+		MsgReturnZoneMismatch:        "la devolución debe usar la zona original %[1]s",
+		MsgReturnCOD:                 "las etiquetas de devolución no admiten pago contra reembolso",
+		MsgCustomsNoRules:            "no hay normas aduaneras para el destino: %[1]s",
+		MsgCustomsNoItems:            "la declaración de aduana no tiene artículos",
+		MsgCustomsInvalidHSCode:      "artículo %[1]d: código SA no válido: %[2]s",
+		MsgCustomsInvalidValue:       "artículo %[1]d: el valor declarado debe ser positivo",
+		MsgCustomsInvalidQuantity:    "artículo %[1]d: la cantidad debe ser positiva",
+		MsgCustomsInvalidOrigin:      "artículo %[1]d: país de origen no válido: %[2]s",
+		MsgCurrencyNoRates:           "no hay tipos de cambio cargados para %[1]s",
+		MsgCurrencyUnsupported:       "moneda no admitida: %[1]s",
+		MsgCurrencyNoRate:            "no hay tipo de cambio de %[1]s a %[2]s",
+		MsgOffsetNoRoute:             "la compensación de carbono no está disponible para la zona %[1]s",
+		MsgRequestRequired:           "la solicitud es obligatoria",
+		MsgRateCardVersionRequired:   "la versión de la tarifa es obligatoria",
+		MsgRateCardAuthorRequired:    "el autor es obligatorio",
+		MsgRateCardEditorRequired:    "el editor es obligatorio",
+		MsgRateCardApproverRequired:  "el aprobador es obligatorio",
+		MsgRateCardRollbackBy:        "el usuario que revierte la tarifa es obligatorio",
+		MsgRateCardEffectivePast:     "la fecha de entrada en vigor no puede ser pasada",
+		MsgRateCardNoZones:           "la tarifa no tiene zonas",
+		MsgRateCardMaxWeight:         "el peso máximo de la tarifa debe ser positivo",
+		MsgRateCardNegativeRate:      "la zona %[1]s de la tarifa tiene un precio negativo",
+		MsgRateCardNegativeSurcharge: "los recargos de la tarifa no pueden ser negativos",
+		MsgWeightUnit:                "unidad de peso desconocida: %[1]s",
+		MsgWeightAmbiguous:           "peso ambiguo %[1]s: podrían ser miles o decimales",
+		MsgZoneAmbiguous:             "zona ambigua %[1]s: podría ser %[2]s",
+		MsgInsuredInvalid:            "valor de seguro no válido: %[1]s",
+
+		"line.shipping":        "Gastos de envío",
+		"line.flat_rate":       "Tarifa plana (%[1]s)",
+		"line.heavy_surcharge": "Recargo por paquete pesado",
+		"line.insurance":       "Seguro",
+		"line.peak_surcharge":  "Recargo de temporada alta (%[1]s)",
+		"line.cod_fee":         "Comisión de contra reembolso",
+		"line.return_discount": "Descuento por etiqueta de devolución (%[1]s)",
+		"line.volume_discount": "Descuento por volumen (%[1]s+ envíos)",
+		"line.minimum_charge":  "Ajuste por importe mínimo",
+		"line.maximum_charge":  "Ajuste por importe máximo",
+		"line.price_ending":    "Redondeo de precio",
+		"line.carbon_offset":   "Compensación de carbono",
+	},
+	"fr": {
+		MsgInvalidWeight:             "poids non valide",
+		MsgInvalidZone:               "zone non valide : %[1]s",
+		MsgPackageUnknown:            "type de colis non valide : %[1]s",
+		MsgPackageTooHeavy:           "%[1]s ne peut pas peser plus de %[2]g kg",
+		MsgPackageBadDimensions:      "dimensions non valides pour %[1]s",
+		MsgPackageTooLarge:           "%[1]s ne peut pas dépasser %[2]gx%[3]gx%[4]g cm",
+		MsgPackageZone:               "%[1]s ne peut pas être envoyé en zone %[2]s",
+		MsgCODInternational:          "le paiement à la livraison n'est pas disponible pour les colis internationaux",
+		MsgCODAmountInvalid:          "le montant à encaisser doit être positif",
+		MsgCODAmountLimit:            "le montant à encaisser dépasse la limite de %.2[1]f",
+		MsgReturnInvalidTracking:     "numéro de suivi non valide : %[1]s",
+		MsgReturnUnavailable:         "les étiquettes de retour ne sont pas disponibles",
+		MsgReturnNotFound:            "étiquette de retour pour %[1]s : envoi introuvable",
+		MsgReturnNotReturnable:       "l'envoi %[1]s ne peut pas être retourné tant qu'il est à l'état %[2]s",
+		MsgReturnZoneMismatch:        "le retour doit utiliser la zone d'origine %[1]s",
+		MsgReturnCOD:                 "les étiquettes de retour ne peuvent pas être en paiement à la livraison",
+		MsgCustomsNoRules:            "aucune règle douanière pour la destination : %[1]s",
+		MsgCustomsNoItems:            "la déclaration en douane ne contient aucun article",
+		MsgCustomsInvalidHSCode:      "article %[1]d : code SH non valide : %[2]s",
+		MsgCustomsInvalidValue:       "article %[1]d : la valeur déclarée doit être positive",
+		MsgCustomsInvalidQuantity:    "article %[1]d : la quantité doit être positive",
+		MsgCustomsInvalidOrigin:      "article %[1]d : pays d'origine non valide : %[2]s",
+		MsgCurrencyNoRates:           "aucun taux de change chargé pour %[1]s",
+		MsgCurrencyUnsupported:       "devise non prise en charge : %[1]s",
+		MsgCurrencyNoRate:            "aucun taux de change de %[1]s vers %[2]s",
+		MsgOffsetNoRoute:             "la compensation carbone n'est pas disponible pour la zone %[1]s",
+		MsgRequestRequired:           "la requête est obligatoire",
+		MsgRateCardVersionRequired:   "la version de la grille tarifaire est obligatoire",
+		MsgRateCardAuthorRequired:    "l'auteur est obligatoire",
+		MsgRateCardEditorRequired:    "l'éditeur est obligatoire",
+		MsgRateCardApproverRequired:  "l'approbateur est obligatoire",
+		MsgRateCardRollbackBy:        "l'utilisateur qui annule la grille tarifaire est obligatoire",
+		MsgRateCardEffectivePast:     "la date d'entrée en vigueur ne peut pas être passée",
+		MsgRateCardNoZones:           "la grille tarifaire n'a aucune zone",
+		MsgRateCardMaxWeight:         "le poids maximal de la grille tarifaire doit être positif",
+		MsgRateCardNegativeRate:      "la zone %[1]s de la grille tarifaire a un tarif négatif",
+		MsgRateCardNegativeSurcharge: "les suppléments de la grille tarifaire ne peuvent pas être négatifs",
+		MsgWeightUnit:                "unité de poids inconnue : %[1]s",
+		MsgWeightAmbiguous:           "poids ambigu %[1]s : milliers ou décimales ?",
+		MsgZoneAmbiguous:             "zone ambiguë %[1]s : peut être %[2]s",
+		MsgInsuredInvalid:            "valeur d'assurance non valide : %[1]s",
+
+		"line.shipping":        "Frais de port",
+		"line.flat_rate":       "Forfait (%[1]s)",
+		"line.heavy_surcharge": "Supplément colis lourd",
+		"line.insurance":       "Assurance",
+		"line.peak_surcharge":  "Supplément haute saison (%[1]s)",
+		"line.cod_fee":         "Frais de paiement à la livraison",
+		"line.return_discount": "Remise étiquette de retour (%[1]s)",
+		"line.volume_discount": "Remise sur volume (%[1]s+ envois)",
+		"line.minimum_charge":  "Ajustement au montant minimum",
+		"line.maximum_charge":  "Ajustement au montant maximum",
+		"line.price_ending":    "Arrondi du prix",
+		"line.carbon_offset":   "Compensation carbone",
+	},
+	"de": {
+		MsgInvalidWeight:             "ungültiges Gewicht",
+		MsgInvalidZone:               "ungültige Zone: %[1]s",
+		MsgPackageUnknown:            "ungültiger Pakettyp: %[1]s",
+		MsgPackageTooHeavy:           "%[1]s darf höchstens %[2]g kg wiegen",
+		MsgPackageBadDimensions:      "ungültige Maße für %[1]s",
+		MsgPackageTooLarge:           "%[1]s darf %[2]gx%[3]gx%[4]g cm nicht überschreiten",
+		MsgPackageZone:               "%[1]s kann nicht in Zone %[2]s versendet werden",
+		MsgCODInternational:          "Nachnahme ist für internationale Sendungen nicht verfügbar",
+		MsgCODAmountInvalid:          "der Nachnahmebetrag muss positiv sein",
+		MsgCODAmountLimit:            "der Nachnahmebetrag überschreitet das Limit von %.2[1]f",
+		MsgReturnInvalidTracking:     "ungültige Sendungsnummer: %[1]s",
+		MsgReturnUnavailable:         "Retourenetiketten sind nicht verfügbar",
+		MsgReturnNotFound:            "Retourenetikett für %[1]s: Sendung nicht gefunden",
+		MsgReturnNotReturnable:       "Sendung %[1]s kann im Status %[2]s nicht retourniert werden",
+		MsgReturnZoneMismatch:        "die Retoure muss die ursprüngliche Zone %[1]s verwenden",
+		MsgReturnCOD:                 "Retourenetiketten können nicht per Nachnahme versendet werden",
+		MsgCustomsNoRules:            "keine Zollregeln für das Zielland: %[1]s",
+		MsgCustomsNoItems:            "die Zollanmeldung enthält keine Artikel",
+		MsgCustomsInvalidHSCode:      "Artikel %[1]d: ungültiger HS-Code: %[2]s",
+		MsgCustomsInvalidValue:       "Artikel %[1]d: der angegebene Wert muss positiv sein",
+		MsgCustomsInvalidQuantity:    "Artikel %[1]d: die Menge muss positiv sein",
+		MsgCustomsInvalidOrigin:      "Artikel %[1]d: ungültiges Ursprungsland: %[2]s",
+		MsgCurrencyNoRates:           "keine Wechselkurse für %[1]s geladen",
+		MsgCurrencyUnsupported:       "nicht unterstützte Währung: %[1]s",
+		MsgCurrencyNoRate:            "kein Wechselkurs von %[1]s nach %[2]s",
+		MsgOffsetNoRoute:             "für die Zone %[1]s ist keine CO2-Kompensation verfügbar",
+		MsgRequestRequired:           "die Anfrage ist erforderlich",
+		MsgRateCardVersionRequired:   "die Tarifversion ist erforderlich",
+		MsgRateCardAuthorRequired:    "der Autor ist erforderlich",
+		MsgRateCardEditorRequired:    "der Bearbeiter ist erforderlich",
+		MsgRateCardApproverRequired:  "der Genehmigende ist erforderlich",
+		MsgRateCardRollbackBy:        "der zurücksetzende Benutzer ist erforderlich",
+		MsgRateCardEffectivePast:     "das Gültigkeitsdatum darf nicht in der Vergangenheit liegen",
+		MsgRateCardNoZones:           "der Tarif hat keine Zonen",
+		MsgRateCardMaxWeight:         "das Höchstgewicht des Tarifs muss positiv sein",
+		MsgRateCardNegativeRate:      "die Tarifzone %[1]s hat einen negativen Preis",
+		MsgRateCardNegativeSurcharge: "die Zuschläge des Tarifs dürfen nicht negativ sein",
+		MsgWeightUnit:                "unbekannte Gewichtseinheit: %[1]s",
+		MsgWeightAmbiguous:           "mehrdeutiges Gewicht %[1]s: Tausender oder Dezimalstellen?",
+		MsgZoneAmbiguous:             "mehrdeutige Zone %[1]s: möglich sind %[2]s",
+		MsgInsuredInvalid:            "ungültiger Versicherungswert: %[1]s",
+
+		"line.shipping":        "Versandkosten",
+		"line.flat_rate":       "Pauschalpreis (%[1]s)",
+		"line.heavy_surcharge": "Zuschlag für schwere Pakete",
+		"line.insurance":       "Versicherung",
+		"line.peak_surcharge":  "Saisonzuschlag (%[1]s)",
+		"line.cod_fee":         "Nachnahmegebühr",
+		"line.return_discount": "Rabatt für Retourenetikett (%[1]s)",
+		"line.volume_discount": "Mengenrabatt (ab %[1]s Sendungen)",
+		"line.minimum_charge":  "Anpassung an Mindestpreis",
+		"line.maximum_charge":  "Anpassung an Höchstpreis",
+		"line.price_ending":    "Preisrundung",
+		"line.carbon_offset":   "CO2-Kompensation",
+	},
+}
+
+// Locales returns the locales the catalogue has, sorted.
+func (c Catalogue) Locales() []string {
+	locales := make([]string, 0, len(c))
+	for l := range c {
+		locales = append(locales, l)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Format renders the message for code in locale, falling back to English
+// and finally to the code itself.
+func (c Catalogue) Format(locale, code string, args ...any) string {
+	format, ok := c[locale][code]
+	if !ok {
+		format, ok = c[DefaultLocale][code]
+	}
+	if !ok {
+		return code
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// NegotiateLocale picks the best available locale for an Accept-Language
+// header value such as "fr-CH, fr;q=0.9, en;q=0.8". A regional tag matches
+// its base language when the region is not available. Tags with q=0 are
+// never chosen. DefaultLocale is returned when nothing matches.
+func (c Catalogue) NegotiateLocale(acceptLanguage string) string {
+	type candidate struct {
+		tag string
+		q   float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{tag, q})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+
+	for _, cand := range candidates {
+		if cand.tag == "*" {
+			return DefaultLocale
+		}
+		tag := strings.ReplaceAll(cand.tag, "_", "-")
+		if _, ok := c[tag]; ok {
+			return tag
+		}
+		if base, _, ok := strings.Cut(tag, "-"); ok {
+			if _, ok := c[base]; ok {
+				return base
+			}
+		}
+	}
+	return DefaultLocale
+}
+
+// LocalizeError returns err's message in locale. Errors without a message
+// code are returned unchanged.
+func LocalizeError(err error, locale string) string {
+	var fe *FieldError
+	if errors.As(err, &fe) && fe.Code != "" {
+		return Messages.Format(locale, fe.Code, fe.Args...)
+	}
+	return err.Error()
+}
+
+// LocalizeQuote returns a copy of q with line descriptions in locale.
+func LocalizeQuote(q Quote, locale string) Quote {
+	q.Lines = localizeLines(q.Lines, locale)
+	if q.Presentment != nil {
+		p := *q.Presentment
+		p.Lines = localizeLines(p.Lines, locale)
+		q.Presentment = &p
+	}
+	return q
+}
+
+func localizeLines(lines []LineItem, locale string) []LineItem {
+	out := make([]LineItem, len(lines))
+	for i, line := range lines {
+		if _, known := Messages[DefaultLocale]["line."+line.Code]; known {
+			args := make([]any, len(line.Args))
+			for j, a := range line.Args {
+				args[j] = a
+			}
+			line.Description = Messages.Format(locale, "line."+line.Code, args...)
+		}
+		out[i] = line
+	}
+	return out
+}
+
+// newLine builds a line item whose description comes from the English catalogue.
+func newLine(code string, amount float64, args ...string) LineItem {
+	formatArgs := make([]any, len(args))
+	for i, a := range args {
+		formatArgs[i] = a
+	}
+	return LineItem{
+		Code:        code,
+		Description: Messages.Format(DefaultLocale, "line."+code, formatArgs...),
+		Amount:      amount,
+		Args:        args,
+	}
+}
@@ -0,0 +1,116 @@
+// messages_test.go
+package shipping
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+)
+
+func TestNegotiateLocale(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", "en"},
+		{"fr", "fr"},
+		{"fr-CH, fr;q=0.9, en;q=0.8", "fr"},
+		{"en;q=0.5, de;q=0.9", "de"},
+		{"es_ES", "es"},
+		{"ja, zh;q=0.8", "en"},
+		{"de;q=0, fr;q=0.1", "fr"},
+		{"*", "en"},
+		{"DE-AT", "de"},
+		{"fr;q=abc, es", "es"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if got := Messages.NegotiateLocale(tt.header); got != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCatalogueFallback(t *testing.T) {
+	c := Catalogue{
+		"en": {"greeting": "hello %[1]s", "only_en": "english"},
+		"fr": {"greeting": "bonjour %[1]s"},
+	}
+
+	if got := c.Format("fr", "greeting", "Ana"); got != "bonjour Ana" {
+		t.Errorf("Expected French, got %q", got)
+	}
+	if got := c.Format("fr", "only_en"); got != "english" {
+		t.Errorf("Expected the English fallback, got %q", got)
+	}
+	if got := c.Format("xx", "missing"); got != "missing" {
+		t.Errorf("Expected the code itself, got %q", got)
+	}
+}
+
+// Every translation must use the same arguments as the English message,
+// so the same Args render correctly in any language.
+func TestCatalogueComplete(t *testing.T) {
+	verb := regexp.MustCompile(`%[^\[]*\[(\d+)\]`)
+	args := func(format string) map[string]bool {
+		set := map[string]bool{}
+		for _, m := range verb.FindAllStringSubmatch(format, -1) {
+			set[m[1]] = true
+		}
+		return set
+	}
+
+	for _, locale := range Messages.Locales() {
+		for code, english := range Messages[DefaultLocale] {
+			format, ok := Messages[locale][code]
+			if !ok {
+				t.Errorf("%s: missing %s", locale, code)
+				continue
+			}
+			want, got := args(english), args(format)
+			if len(want) != len(got) {
+				t.Errorf("%s: %s uses %d arguments, English uses %d", locale, code, len(got), len(want))
+			}
+		}
+	}
+}
+
+func TestLocalizeError(t *testing.T) {
+	_, err := (&Calculator{}).Quote(QuoteRequest{Weight: 2, Zone: "Nowhere"})
+	if got := LocalizeError(err, "de"); got != "ungültige Zone: Nowhere" {
+		t.Errorf("Expected a German message, got %q", got)
+	}
+	if got := LocalizeError(err, "en"); got != err.Error() {
+		t.Errorf("Expected the English message to match Error(), got %q", got)
+	}
+
+	plain := errors.New("database unavailable")
+	if got := LocalizeError(plain, "fr"); got != "database unavailable" {
+		t.Errorf("Expected errors without a code unchanged, got %q", got)
+	}
+}
+
+func TestLocalizeQuote(t *testing.T) {
+	q := Quote{
+		Currency: "USD",
+		Lines: []LineItem{
+			newLine("shipping", 10),
+			newLine("peak_surcharge", 2, "Holidays"),
+			{Code: "custom", Description: "Handling", Amount: 1},
+		},
+		Total: 13,
+	}
+
+	fr := LocalizeQuote(q, "fr")
+	want := []string{"Frais de port", "Supplément haute saison (Holidays)", "Handling"}
+	for i, line := range fr.Lines {
+		if line.Description != want[i] {
+			t.Errorf("Expected %q, got %q", want[i], line.Description)
+		}
+	}
+	if q.Lines[0].Description != "Shipping fee" {
+		t.Errorf("Expected the original quote to be unchanged, got %q", q.Lines[0].Description)
+	}
+}
@@ -0,0 +1,235 @@
+// metrics.go
+package shipping
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// QuoteObservation describes one call to the calculator.
+type QuoteObservation struct {
+	Zone     string
+	Version  string // rate card version
+	Duration time.Duration
+	Err      error
+}
+
+// Outcome is "ok" or "error".
+func (o QuoteObservation) Outcome() string {
+	if o.Err != nil {
+		return "error"
+	}
+	return "ok"
+}
+
+// Reason is a short, low-cardinality label for why a quote failed, such
+// as "invalid_weight". It is empty for successful quotes.
+func (o QuoteObservation) Reason() string {
+	return ErrorReason(o.Err)
+}
+
+// ErrorReason classifies err for metrics.
+func ErrorReason(err error) string {
+	var fe *FieldError
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &fe):
+		field, _, _ := strings.Cut(fe.Field, "[")
+		field, _, _ = strings.Cut(field, ".")
+		return "invalid_" + field
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "deadline_exceeded"
+	default:
+		return "internal"
+	}
+}
+
+// Observer receives an observation for every quote. Implementations must
+// be safe for concurrent use and should return quickly.
+type Observer interface {
+	ObserveQuote(ctx context.Context, o QuoteObservation)
+}
+
+// MultiObserver fans observations out to several observers.
+type MultiObserver []Observer
+
+// ObserveQuote implements Observer.
+func (m MultiObserver) ObserveQuote(ctx context.Context, o QuoteObservation) {
+	for _, obs := range m {
+		obs.ObserveQuote(ctx, o)
+	}
+}
+
+// Attribute is a metric label, matching OpenTelemetry's key/value attributes.
+type Attribute struct {
+	Key   string
+	Value string
+}
+
+// Meter is the subset of an OpenTelemetry meter the calculator uses: a
+// counter and a histogram. An adapter over go.opentelemetry.io/otel/metric
+// forwards Add to an Int64Counter and Record to a Float64Histogram, turning
+// the attributes into attribute.String values.
+type Meter interface {
+	Add(ctx context.Context, name string, incr int64, attrs ...Attribute)
+	Record(ctx context.Context, name string, value float64, attrs ...Attribute)
+}
+
+// Metric names shared by the Prometheus and OpenTelemetry observers.
+const (
+	MetricQuotes        = "shipping_quotes_total"
+	MetricQuoteErrors   = "shipping_quote_errors_total"
+	MetricQuoteDuration = "shipping_quote_duration_seconds"
+)
+
+// MeterObserver records observations through an OpenTelemetry-style Meter.
+type MeterObserver struct {
+	Meter Meter
+}
+
+// ObserveQuote implements Observer.
+func (m MeterObserver) ObserveQuote(ctx context.Context, o QuoteObservation) {
+	attrs := []Attribute{{"zone", o.Zone}, {"version", o.Version}}
+	m.Meter.Add(ctx, MetricQuotes, 1, append(attrs, Attribute{"outcome", o.Outcome()})...)
+	if o.Err != nil {
+		m.Meter.Add(ctx, MetricQuoteErrors, 1, append(attrs, Attribute{"reason", o.Reason()})...)
+	}
+	m.Meter.Record(ctx, MetricQuoteDuration, o.Duration.Seconds(), attrs...)
+}
+
+// DefaultDurationBuckets are the histogram bounds, in seconds. Quotes are
+// in-memory arithmetic unless a stage talks to a database.
+var DefaultDurationBuckets = []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1}
+
+// PrometheusObserver keeps counters and latency histograms in memory and
+// serves them in the Prometheus text exposition format. The zero value is
+// ready to use with DefaultDurationBuckets.
+type PrometheusObserver struct {
+	// Buckets are the histogram bucket upper bounds in seconds. Each
+	// histogram keeps the bounds it was created with, so changing Buckets
+	// only affects zones and versions not seen yet.
+	Buckets []float64
+
+	mu        sync.Mutex
+	quotes    map[[3]string]uint64 // zone, version, outcome
+	errors    map[[3]string]uint64 // zone, version, reason
+	durations map[[2]string]*histogram
+}
+
+type histogram struct {
+	bounds []float64
+	counts []uint64 // per bucket, not cumulative
+	sum    float64
+	count  uint64
+}
+
+// NewPrometheusObserver returns an observer using DefaultDurationBuckets.
+func NewPrometheusObserver() *PrometheusObserver {
+	return &PrometheusObserver{Buckets: DefaultDurationBuckets}
+}
+
+// ObserveQuote implements Observer.
+func (p *PrometheusObserver) ObserveQuote(ctx context.Context, o QuoteObservation) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.quotes == nil {
+		p.quotes = make(map[[3]string]uint64)
+		p.errors = make(map[[3]string]uint64)
+		p.durations = make(map[[2]string]*histogram)
+	}
+
+	p.quotes[[3]string{o.Zone, o.Version, o.Outcome()}]++
+	if o.Err != nil {
+		p.errors[[3]string{o.Zone, o.Version, o.Reason()}]++
+	}
+
+	key := [2]string{o.Zone, o.Version}
+	h, ok := p.durations[key]
+	if !ok {
+		buckets := p.Buckets
+		if buckets == nil {
+			buckets = DefaultDurationBuckets
+		}
+		h = &histogram{bounds: slices.Clone(buckets), counts: make([]uint64, len(buckets))}
+		p.durations[key] = h
+	}
+	seconds := o.Duration.Seconds()
+	for i, bound := range h.bounds {
+		if seconds <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// ServeHTTP writes the metrics in the Prometheus text format.
+func (p *PrometheusObserver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	fmt.Fprintf(w, "# HELP %s Shipping quotes by zone, rate card version and outcome.\n", MetricQuotes)
+	fmt.Fprintf(w, "# TYPE %s counter\n", MetricQuotes)
+	for _, k := range sortedKeys3(p.quotes) {
+		fmt.Fprintf(w, "%s{zone=\"%s\",version=\"%s\",outcome=\"%s\"} %d\n", MetricQuotes, escapeLabel(k[0]), escapeLabel(k[1]), k[2], p.quotes[k])
+	}
+
+	fmt.Fprintf(w, "# HELP %s Failed shipping quotes by zone, rate card version and reason.\n", MetricQuoteErrors)
+	fmt.Fprintf(w, "# TYPE %s counter\n", MetricQuoteErrors)
+	for _, k := range sortedKeys3(p.errors) {
+		fmt.Fprintf(w, "%s{zone=\"%s\",version=\"%s\",reason=\"%s\"} %d\n", MetricQuoteErrors, escapeLabel(k[0]), escapeLabel(k[1]), k[2], p.errors[k])
+	}
+
+	fmt.Fprintf(w, "# HELP %s Time taken to price a quote.\n", MetricQuoteDuration)
+	fmt.Fprintf(w, "# TYPE %s histogram\n", MetricQuoteDuration)
+	keys := make([][2]string, 0, len(p.durations))
+	for k := range p.durations {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i][0]+"\x00"+keys[i][1] < keys[j][0]+"\x00"+keys[j][1] })
+	for _, k := range keys {
+		h := p.durations[k]
+		labels := fmt.Sprintf("zone=\"%s\",version=\"%s\"", escapeLabel(k[0]), escapeLabel(k[1]))
+		var cumulative uint64
+		for i, bound := range h.bounds {
+			cumulative += h.counts[i]
+			fmt.Fprintf(w, "%s_bucket{%s,le=%q} %d\n", MetricQuoteDuration, labels, strconv.FormatFloat(bound, 'g', -1, 64), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket{%s,le=\"+Inf\"} %d\n", MetricQuoteDuration, labels, h.count)
+		fmt.Fprintf(w, "%s_sum{%s} %g\n", MetricQuoteDuration, labels, h.sum)
+		fmt.Fprintf(w, "%s_count{%s} %d\n", MetricQuoteDuration, labels, h.count)
+	}
+}
+
+// labelEscaper escapes label values as the exposition format requires.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(v string) string {
+	return labelEscaper.Replace(v)
+}
+
+func sortedKeys3(m map[[3]string]uint64) [][3]string {
+	keys := make([][3]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return strings.Join(keys[i][:], "\x00") < strings.Join(keys[j][:], "\x00")
+	})
+	return keys
+}
@@ -0,0 +1,132 @@
+// metrics_test.go
+package shipping
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestPrometheusObserver(t *testing.T) {
+	prom := NewPrometheusObserver()
+	c := Calculator{Observer: prom}
+
+	c.Quote(QuoteRequest{Weight: 10, Zone: "Domestic"})
+	c.Quote(QuoteRequest{Weight: 5, Zone: "Domestic"})
+	c.Quote(QuoteRequest{Weight: 0, Zone: "Domestic"})
+	c.Quote(QuoteRequest{Weight: 5, Zone: "Mars"})
+
+	rr := httptest.NewRecorder()
+	prom.ServeHTTP(rr, httptest.NewRequest("GET", "/metrics", nil))
+	body := rr.Body.String()
+
+	expected := []string{
+		`shipping_quotes_total{zone="Domestic",version="standard",outcome="ok"} 2`,
+		`shipping_quotes_total{zone="Domestic",version="standard",outcome="error"} 1`,
+		`shipping_quote_errors_total{zone="Domestic",version="standard",reason="invalid_weight"} 1`,
+		// Unknown zones are grouped so arbitrary input cannot create new series
+		`shipping_quote_errors_total{zone="unknown",version="standard",reason="invalid_zone"} 1`,
+		`shipping_quote_duration_seconds_bucket{zone="Domestic",version="standard",le="+Inf"} 3`,
+		`shipping_quote_duration_seconds_count{zone="Domestic",version="standard"} 3`,
+		"# TYPE shipping_quote_duration_seconds histogram",
+	}
+	for _, line := range expected {
+		if !strings.Contains(body, line) {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", line, body)
+		}
+	}
+	if !strings.HasPrefix(rr.Header().Get("Content-Type"), "text/plain; version=0.0.4") {
+		t.Errorf("Unexpected content type %q", rr.Header().Get("Content-Type"))
+	}
+}
+
+func TestPrometheusObserverZeroValue(t *testing.T) {
+	var prom PrometheusObserver
+	c := Calculator{Observer: &prom}
+	c.Quote(QuoteRequest{Weight: 10, Zone: "Domestic"})
+
+	// Histograms already recorded keep their buckets
+	prom.Buckets = []float64{1}
+	c.Quote(QuoteRequest{Weight: 10, Zone: "Domestic"})
+	c.Quote(QuoteRequest{Weight: 10, Zone: "Express"})
+
+	rr := httptest.NewRecorder()
+	prom.ServeHTTP(rr, httptest.NewRequest("GET", "/metrics", nil))
+	body := rr.Body.String()
+
+	expected := []string{
+		`shipping_quote_duration_seconds_bucket{zone="Domestic",version="standard",le="0.0001"}`,
+		`shipping_quote_duration_seconds_count{zone="Domestic",version="standard"} 2`,
+		`shipping_quote_duration_seconds_bucket{zone="Express",version="standard",le="1"} 1`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(body, line) {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", line, body)
+		}
+	}
+	if strings.Contains(body, `zone="Express",version="standard",le="0.0001"`) {
+		t.Errorf("Expected Express to use the new buckets, got:\n%s", body)
+	}
+}
+
+// recordingMeter captures calls the way an OpenTelemetry adapter would receive them.
+type recordingMeter struct {
+	mu       sync.Mutex
+	counters map[string]int64
+	records  int
+}
+
+func (m *recordingMeter) Add(ctx context.Context, name string, incr int64, attrs ...Attribute) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := name
+	for _, a := range attrs {
+		key += "," + a.Key + "=" + a.Value
+	}
+	m.counters[key] += incr
+}
+
+func (m *recordingMeter) Record(ctx context.Context, name string, value float64, attrs ...Attribute) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.records++
+}
+
+func TestMeterObserver(t *testing.T) {
+	meter := &recordingMeter{counters: make(map[string]int64)}
+	prom := NewPrometheusObserver()
+	c := Calculator{Card: &TieredRateCard, Observer: MultiObserver{MeterObserver{Meter: meter}, prom}}
+
+	c.Quote(QuoteRequest{Weight: 10, Zone: "Express"})
+	c.Quote(QuoteRequest{Weight: 10, Zone: "Express", Package: "crate"})
+
+	if got := meter.counters["shipping_quotes_total,zone=Express,version=tiered,outcome=ok"]; got != 1 {
+		t.Errorf("Expected 1 successful quote, got %d (%v)", got, meter.counters)
+	}
+	if got := meter.counters["shipping_quote_errors_total,zone=Express,version=tiered,reason=invalid_package"]; got != 1 {
+		t.Errorf("Expected 1 invalid_package error, got %d (%v)", got, meter.counters)
+	}
+	if meter.records != 2 {
+		t.Errorf("Expected 2 latency records, got %d", meter.records)
+	}
+}
+
+func TestErrorReason(t *testing.T) {
+	testCases := []struct {
+		err      error
+		expected string
+	}{
+		{nil, ""},
+		{fieldError("items[2].hs_code", "bad"), "invalid_items"},
+		{fieldError("cod.amount", "bad"), "invalid_cod"},
+		{context.DeadlineExceeded, "deadline_exceeded"},
+		{ErrShipmentNotFound, "internal"},
+	}
+	for _, tc := range testCases {
+		if got := ErrorReason(tc.err); got != tc.expected {
+			t.Errorf("ErrorReason(%v): expected %q, got %q", tc.err, tc.expected, got)
+		}
+	}
+}
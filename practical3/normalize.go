@@ -0,0 +1,306 @@
+// normalize.go
+package shipping
+
+import (
+	"context"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// RawQuoteRequest is a quote request as typed into a form, before any
+// parsing. Every field is free text.
+type RawQuoteRequest struct {
+	Weight  string `json:"weight"`
+	Zone    string `json:"zone"`
+	Insured string `json:"insured"`
+}
+
+// RawQuoteRequestFromForm reads the weight, zone and insured form fields.
+func RawQuoteRequestFromForm(form url.Values) RawQuoteRequest {
+	return RawQuoteRequest{
+		Weight:  form.Get("weight"),
+		Zone:    form.Get("zone"),
+		Insured: form.Get("insured"),
+	}
+}
+
+// Normalization records one change made to the caller's input, so forms
+// can show what was understood.
+type Normalization struct {
+	Field string `json:"field"`
+	Input string `json:"input"`
+	Value string `json:"value"`
+	Rule  string `json:"rule"`
+}
+
+// DefaultZoneAliases maps common names for the standard zones, in lower
+// case, to the zone.
+var DefaultZoneAliases = map[string]string{
+	"dom":          "Domestic",
+	"national":     "Domestic",
+	"intl":         "International",
+	"int'l":        "International",
+	"abroad":       "International",
+	"overseas":     "International",
+	"exp":          "Express",
+	"priority":     "Express",
+	"next day":     "Express",
+	"next-day":     "Express",
+	"overnight":    "Express",
+	"express mail": "Express",
+}
+
+// weightUnits converts each accepted unit to kilograms.
+var weightUnits = map[string]float64{
+	"kg": 1, "kgs": 1, "kilo": 1, "kilos": 1, "kilogram": 1, "kilograms": 1,
+	"g": 0.001, "gr": 0.001, "gram": 0.001, "grams": 0.001,
+	"lb": 0.45359237, "lbs": 0.45359237, "pound": 0.45359237, "pounds": 0.45359237,
+	"oz": 0.028349523125, "ounce": 0.028349523125, "ounces": 0.028349523125,
+}
+
+// Normalizer turns free-form input into a QuoteRequest. It is forgiving
+// about case, spacing, units and common synonyms, but rejects input that
+// could reasonably mean two different things.
+type Normalizer struct {
+	// Zones are the canonical zone names.
+	Zones []string
+	// Aliases maps lower-case alternative names to zones.
+	Aliases map[string]string
+}
+
+// Normalizer returns a normalizer for the zones of the calculator's rate
+// card in effect now and DefaultZoneAliases. When there is no card in
+// effect it knows no zones, so every zone is rejected as Quote would.
+func (c *Calculator) Normalizer() Normalizer {
+	card, _ := c.RateCard(context.Background())
+	zones := make([]string, 0, len(card.Zones))
+	for z := range card.Zones {
+		zones = append(zones, z)
+	}
+	sort.Strings(zones)
+	return Normalizer{Zones: zones, Aliases: DefaultZoneAliases}
+}
+
+// Normalize parses raw and lists every normalization applied. The first
+// field that cannot be understood is returned as a *FieldError.
+func (n Normalizer) Normalize(raw RawQuoteRequest) (QuoteRequest, []Normalization, error) {
+	var req QuoteRequest
+	var notes []Normalization
+
+	weight, note, err := ParseWeight(raw.Weight)
+	if err != nil {
+		return QuoteRequest{}, nil, err
+	}
+	req.Weight = weight
+	notes = appendNote(notes, note)
+
+	zone, note, err := n.Zone(raw.Zone)
+	if err != nil {
+		return QuoteRequest{}, nil, err
+	}
+	req.Zone = zone
+	notes = appendNote(notes, note)
+
+	insured, note, err := ParseInsured(raw.Insured)
+	if err != nil {
+		return QuoteRequest{}, nil, err
+	}
+	req.Insured = insured
+	notes = appendNote(notes, note)
+
+	return req, notes, nil
+}
+
+func appendNote(notes []Normalization, note *Normalization) []Normalization {
+	if note == nil {
+		return notes
+	}
+	return append(notes, *note)
+}
+
+// Zone resolves s to a canonical zone. Matching is tried exactly, then
+// ignoring case and spacing, then by alias, then by an unambiguous prefix
+// of at least three letters.
+func (n Normalizer) Zone(s string) (string, *Normalization, error) {
+	for _, z := range n.Zones {
+		if s == z {
+			return z, nil, nil
+		}
+	}
+
+	key := strings.ToLower(strings.Join(strings.Fields(s), " "))
+	note := func(zone, rule string) *Normalization {
+		return &Normalization{Field: "zone", Input: s, Value: zone, Rule: rule}
+	}
+
+	for _, z := range n.Zones {
+		if strings.ToLower(z) == key {
+			return z, note(z, "case and spacing"), nil
+		}
+	}
+	if z, ok := n.Aliases[key]; ok && n.hasZone(z) {
+		return z, note(z, "alias"), nil
+	}
+
+	if len(key) >= 3 {
+		var matches []string
+		for _, z := range n.Zones {
+			if strings.HasPrefix(strings.ToLower(z), key) {
+				matches = append(matches, z)
+			}
+		}
+		switch len(matches) {
+		case 1:
+			return matches[0], note(matches[0], "prefix"), nil
+		case 0:
+		default:
+			return "", nil, fieldError("zone", MsgZoneAmbiguous, s, strings.Join(matches, ", "))
+		}
+	}
+	return "", nil, fieldError("zone", MsgInvalidZone, s)
+}
+
+func (n Normalizer) hasZone(zone string) bool {
+	for _, z := range n.Zones {
+		if z == zone {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseWeight parses a weight such as "2.5", "2,5 kg", "500g" or "3 lbs"
+// into kilograms. A number with no unit is in kilograms. A lone comma or
+// point followed by exactly three digits, as in "1,500" or "1.500", could
+// be a decimal or a thousands separator depending on the locale and is
+// rejected, unless the digits before it could not be a thousands group,
+// as in "0.125". When both appear, the later one is the decimal point and
+// the earlier one must group the digits in threes, so "1,2.5" is rejected.
+func ParseWeight(s string) (float64, *Normalization, error) {
+	text := strings.TrimSpace(s)
+	if text == "" {
+		return 0, nil, fieldError("weight", MsgInvalidWeight)
+	}
+
+	// The number ends after its last digit or separator, which may be
+	// more than one byte long
+	split := 0
+	if i := strings.LastIndexFunc(text, func(r rune) bool { return unicode.IsDigit(r) || r == '.' || r == ',' }); i >= 0 {
+		r, _ := utf8.DecodeRuneInString(text[i:])
+		split = i + utf8.RuneLen(r)
+	}
+	number, unit := strings.TrimSpace(text[:split]), strings.ToLower(strings.TrimSpace(text[split:]))
+
+	factor := 1.0
+	if unit != "" {
+		f, ok := weightUnits[strings.TrimSuffix(unit, ".")]
+		if !ok {
+			return 0, nil, fieldError("weight", MsgWeightUnit, unit)
+		}
+		factor = f
+	}
+
+	var rules []string
+	dot, comma := strings.Contains(number, "."), strings.Contains(number, ",")
+	switch {
+	case dot && comma:
+		// The later separator is the decimal point, and the other one must
+		// split the whole part into groups of three
+		decimal, thousands := ".", ","
+		if strings.LastIndex(number, ",") > strings.LastIndex(number, ".") {
+			decimal, thousands = ",", "."
+		}
+		whole, frac, _ := strings.Cut(number, decimal)
+		if strings.Contains(frac, thousands) || !thousandsGroups(whole, thousands) {
+			return 0, nil, fieldError("weight", MsgWeightAmbiguous, s)
+		}
+		number = strings.ReplaceAll(whole, thousands, "") + "." + frac
+		rules = append(rules, "thousands separator")
+	case comma:
+		if ambiguousSeparator(number, ",") {
+			return 0, nil, fieldError("weight", MsgWeightAmbiguous, s)
+		}
+		number = strings.Replace(number, ",", ".", 1)
+		rules = append(rules, "decimal comma")
+	case dot:
+		if ambiguousSeparator(number, ".") {
+			return 0, nil, fieldError("weight", MsgWeightAmbiguous, s)
+		}
+	}
+
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil || math.IsInf(value, 0) || math.IsNaN(value) {
+		return 0, nil, fieldError("weight", MsgInvalidWeight)
+	}
+
+	kg := value * factor
+	if factor != 1 {
+		kg = math.Round(kg*1000) / 1000
+		rules = append(rules, "converted from "+unit)
+	} else if unit != "" && unit != "kg" {
+		rules = append(rules, "unit "+unit)
+	}
+	if len(rules) == 0 && text == s {
+		return kg, nil, nil
+	}
+	if len(rules) == 0 {
+		rules = append(rules, "spacing")
+	}
+	return kg, &Normalization{
+		Field: "weight",
+		Input: s,
+		Value: strconv.FormatFloat(kg, 'f', -1, 64),
+		Rule:  strings.Join(rules, ", "),
+	}, nil
+}
+
+// thousandsGroups reports whether sep splits whole into a leading group
+// of one to three digits followed by groups of exactly three.
+func thousandsGroups(whole, sep string) bool {
+	groups := strings.Split(whole, sep)
+	if len(groups[0]) < 1 || len(groups[0]) > 3 {
+		return false
+	}
+	for _, g := range groups[1:] {
+		if len(g) != 3 {
+			return false
+		}
+	}
+	return true
+}
+
+// ambiguousSeparator reports whether sep in number could be either a
+// decimal or a thousands separator: it appears more than once, or once
+// with exactly three digits after it and one to three digits before it
+// that do not start with zero.
+func ambiguousSeparator(number, sep string) bool {
+	if strings.Count(number, sep) > 1 {
+		return true
+	}
+	whole, frac, _ := strings.Cut(number, sep)
+	return len(frac) == 3 && len(whole) >= 1 && len(whole) <= 3 && whole[0] != '0'
+}
+
+// ParseInsured reads a yes/no form value. Empty means not insured; an
+// HTML checkbox sends "on".
+func ParseInsured(s string) (bool, *Normalization, error) {
+	key := strings.ToLower(strings.TrimSpace(s))
+	var v bool
+	switch key {
+	case "true", "t", "yes", "y", "1", "on", "checked":
+		v = true
+	case "false", "f", "no", "n", "0", "off", "":
+		v = false
+	default:
+		return false, nil, fieldError("insured", MsgInsuredInvalid, s)
+	}
+	if s == "" || s == strconv.FormatBool(v) {
+		return v, nil, nil
+	}
+	return v, &Normalization{Field: "insured", Input: s, Value: strconv.FormatBool(v), Rule: "boolean"}, nil
+}
@@ -0,0 +1,187 @@
+// normalize_test.go
+package shipping
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+)
+
+func TestParseWeight(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    float64
+		rule    string
+		wantErr bool
+	}{
+		{"2.5", 2.5, "", false},
+		{" 2.5 ", 2.5, "spacing", false},
+		{"2,5", 2.5, "decimal comma", false},
+		{"2,5 kg", 2.5, "decimal comma", false},
+		{"2.5kg", 2.5, "", false},
+		{"3 KGS", 3, "unit kgs", false},
+		{"500g", 0.5, "converted from g", false},
+		{"10 lbs", 4.536, "converted from lbs", false},
+		{"16oz", 0.454, "converted from oz", false},
+		{"1,234.5 g", 1.235, "thousands separator, converted from g", false},
+		{"1.234,5 g", 1.235, "thousands separator, converted from g", false},
+		{"12,345,678.9", 12345678.9, "thousands separator", false},
+		{"1,2.5", 0, "", true},
+		{"1,5.5", 0, "", true},
+		{"1234,567.5", 0, "", true},
+		{"1.234,5.6", 0, "", true},
+		{"1,500", 0, "", true},
+		{"1.500", 0, "", true},
+		{"1.500 kg", 0, "", true},
+		{"12,500 g", 0, "", true},
+		{"0.125", 0.125, "", false},
+		{"0,125 kg", 0.125, "decimal comma", false},
+		{"1234.500 g", 1.235, "converted from g", false},
+		{"1.2.3", 0, "", true},
+		{"1,2,3", 0, "", true},
+		{"5 stone", 0, "", true},
+		{"heavy", 0, "", true},
+		{"", 0, "", true},
+		{"2 kg 500 g", 0, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, note, err := ParseWeight(tt.input)
+			if tt.wantErr {
+				if !IsValidation(err) {
+					t.Errorf("Expected a validation error, got %v (%v)", got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+			rule := ""
+			if note != nil {
+				rule = note.Rule
+			}
+			if rule != tt.rule {
+				t.Errorf("Expected rule %q, got %q", tt.rule, rule)
+			}
+		})
+	}
+
+	// A multi-byte digit is part of the number, not the start of the unit
+	var fe *FieldError
+	if _, _, err := ParseWeight("2٥ kg"); !errors.As(err, &fe) || fe.Code != MsgInvalidWeight {
+		t.Errorf("Expected %s, got %v", MsgInvalidWeight, err)
+	}
+}
+
+func TestNormalizeZone(t *testing.T) {
+	n := (&Calculator{}).Normalizer()
+
+	tests := []struct {
+		input   string
+		want    string
+		rule    string
+		wantErr bool
+	}{
+		{"Domestic", "Domestic", "", false},
+		{"domestic", "Domestic", "case and spacing", false},
+		{"  EXPRESS ", "Express", "case and spacing", false},
+		{"intl", "International", "alias", false},
+		{"Next  Day", "Express", "alias", false},
+		{"inter", "International", "prefix", false},
+		{"do", "", "", true},
+		// Local is not a zone, so it must not be quietly made valid
+		{"local", "", "", true},
+		{"Mars", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, note, err := n.Zone(tt.input)
+			if tt.wantErr {
+				if !IsValidation(err) {
+					t.Errorf("Expected a validation error, got %q (%v)", got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			rule := ""
+			if note != nil {
+				rule = note.Rule
+			}
+			if got != tt.want || rule != tt.rule {
+				t.Errorf("Expected %s (%q), got %s (%q)", tt.want, tt.rule, got, rule)
+			}
+		})
+	}
+}
+
+func TestNormalizeZoneAmbiguous(t *testing.T) {
+	n := Normalizer{Zones: []string{"Express", "Express Plus"}}
+
+	_, _, err := n.Zone("expr")
+	if err == nil || err.Error() != "ambiguous zone expr: could be Express, Express Plus" {
+		t.Errorf("Expected an ambiguous zone error, got %v", err)
+	}
+	if z, _, err := n.Zone("express plus"); err != nil || z != "Express Plus" {
+		t.Errorf("Expected an exact match to win, got %q (%v)", z, err)
+	}
+}
+
+func TestParseInsured(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    bool
+		noted   bool
+		wantErr bool
+	}{
+		{"", false, false, false},
+		{"true", true, false, false},
+		{"false", false, false, false},
+		{"on", true, true, false},
+		{"Yes", true, true, false},
+		{"0", false, true, false},
+		{"maybe", false, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, note, err := ParseInsured(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want || (note != nil) != tt.noted {
+				t.Errorf("Expected %v (noted %v), got %v (%+v)", tt.want, tt.noted, got, note)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	c := &Calculator{}
+	form := url.Values{"weight": {"2,5 kg"}, "zone": {"domestic"}, "insured": {"on"}}
+
+	req, notes, err := c.Normalizer().Normalize(RawQuoteRequestFromForm(form))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if req.Weight != 2.5 || req.Zone != "Domestic" || !req.Insured {
+		t.Errorf("Expected 2.5kg insured Domestic, got %+v", req)
+	}
+	if len(notes) != 3 {
+		t.Errorf("Expected 3 normalizations, got %+v", notes)
+	}
+	if _, err := c.Quote(req); err != nil {
+		t.Errorf("Expected the normalized request to price, got %v", err)
+	}
+
+	_, _, err = c.Normalizer().Normalize(RawQuoteRequest{Weight: "1,500", Zone: "Domestic"})
+	if fe, ok := err.(*FieldError); !ok || fe.Field != "weight" || fe.Code != MsgWeightAmbiguous {
+		t.Errorf("Expected an ambiguous weight error, got %v", err)
+	}
+}
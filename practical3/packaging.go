@@ -0,0 +1,112 @@
+// packaging.go
+package shipping
+
+import "sort"
+
+// Dimensions are a parcel's outer measurements in centimetres.
+type Dimensions struct {
+	Length float64 `json:"length"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// sorted returns the measurements longest first, so parcels can be
+// compared regardless of how they are oriented.
+func (d Dimensions) sorted() [3]float64 {
+	s := []float64{d.Length, d.Width, d.Height}
+	sort.Sort(sort.Reverse(sort.Float64Slice(s)))
+	return [3]float64{s[0], s[1], s[2]}
+}
+
+// FitsWithin reports whether d fits inside max in some orientation.
+func (d Dimensions) FitsWithin(max Dimensions) bool {
+	got, limit := d.sorted(), max.sorted()
+	for i := range got {
+		if got[i] > limit[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// PackageType describes one kind of packaging we accept.
+type PackageType struct {
+	Code          string     `json:"code"`
+	Name          string     `json:"name"`
+	MaxWeight     float64    `json:"max_weight"`
+	MaxDimensions Dimensions `json:"max_dimensions"`
+	// Zones lists where the package type may be sent. Empty means everywhere.
+	Zones []string `json:"zones,omitempty"`
+	// FlatRates, when set, prices the package by zone regardless of weight.
+	FlatRates map[string]float64 `json:"flat_rates,omitempty"`
+}
+
+// PackageCatalogue maps package type codes to their constraints.
+type PackageCatalogue map[string]PackageType
+
+// DefaultPackages is the catalogue used when a Calculator has none configured.
+var DefaultPackages = PackageCatalogue{
+	"envelope": {
+		Code: "envelope", Name: "Envelope",
+		MaxWeight: 0.5, MaxDimensions: Dimensions{35, 25, 2},
+		FlatRates: map[string]float64{"Domestic": 3.00, "International": 9.00, "Express": 12.00},
+	},
+	"small_box": {
+		Code: "small_box", Name: "Small box",
+		MaxWeight: 5, MaxDimensions: Dimensions{40, 30, 20},
+		FlatRates: map[string]float64{"Domestic": 8.00, "International": 30.00, "Express": 40.00},
+	},
+	"tube": {
+		Code: "tube", Name: "Tube",
+		MaxWeight: 3, MaxDimensions: Dimensions{100, 15, 15},
+		Zones: []string{"Domestic", "Express"},
+	},
+	"custom": {
+		Code: "custom", Name: "Custom packaging",
+		MaxWeight: 50, MaxDimensions: Dimensions{150, 100, 100},
+	},
+}
+
+// Validate checks a parcel against the package type's constraints and
+// returns the matching type.
+func (c PackageCatalogue) Validate(code string, weight float64, dims *Dimensions, zone string) (PackageType, error) {
+	pt, ok := c[code]
+	if !ok {
+		return PackageType{}, fieldError("package", MsgPackageUnknown, code)
+	}
+
+	if weight > pt.MaxWeight {
+		return PackageType{}, fieldError("weight", MsgPackageTooHeavy, pt.Name, pt.MaxWeight)
+	}
+	if dims != nil {
+		if dims.Length <= 0 || dims.Width <= 0 || dims.Height <= 0 {
+			return PackageType{}, fieldError("dimensions", MsgPackageBadDimensions, pt.Name)
+		}
+		if !dims.FitsWithin(pt.MaxDimensions) {
+			m := pt.MaxDimensions
+			return PackageType{}, fieldError("dimensions", MsgPackageTooLarge, pt.Name, m.Length, m.Width, m.Height)
+		}
+	}
+	if !pt.allowsZone(zone) {
+		return PackageType{}, fieldError("zone", MsgPackageZone, pt.Name, zone)
+	}
+	return pt, nil
+}
+
+// FlatRate returns the flat price for zone, if the package type has one.
+func (pt PackageType) FlatRate(zone string) (float64, bool) {
+	fee, ok := pt.FlatRates[zone]
+	return fee, ok
+}
+
+func (pt PackageType) allowsZone(zone string) bool {
+	if len(pt.Zones) == 0 {
+		return true
+	}
+	for _, z := range pt.Zones {
+		if z == zone {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+// packaging_test.go
+package shipping
+
+import "testing"
+
+func TestPackageCatalogue_Validate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        string
+		weight      float64
+		dims        *Dimensions
+		zone        string
+		expectError bool
+	}{
+		{"Envelope within limits", "envelope", 0.2, &Dimensions{30, 20, 1}, "Domestic", false},
+		{"Envelope at max weight", "envelope", 0.5, nil, "Domestic", false},
+		{"Envelope too heavy", "envelope", 0.6, nil, "Domestic", true},
+		{"Rotated box still fits", "small_box", 2, &Dimensions{20, 40, 30}, "Domestic", false},
+		{"Box too long", "small_box", 2, &Dimensions{41, 30, 20}, "Domestic", true},
+		{"Tube not sent abroad", "tube", 1, nil, "International", true},
+		{"Tube sent Express", "tube", 1, &Dimensions{90, 10, 10}, "Express", false},
+		{"Negative dimension", "custom", 1, &Dimensions{-1, 10, 10}, "Domestic", true},
+		{"Unknown type", "crate", 1, nil, "Domestic", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := DefaultPackages.Validate(tc.code, tc.weight, tc.dims, tc.zone)
+			if tc.expectError && err == nil {
+				t.Errorf("Expected an error, but got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("Expected no error, but got: %v", err)
+			}
+		})
+	}
+}
+
+func TestCalculator_QuotePackages(t *testing.T) {
+	var c Calculator
+
+	testCases := []struct {
+		name         string
+		req          QuoteRequest
+		expectedCode string
+		expectedFee  float64
+	}{
+		// Flat-rate packages ignore weight
+		{"Flat-rate small box", QuoteRequest{Weight: 4, Zone: "International", Package: "small_box"}, "flat_rate", 30},
+		// Weighed packages use the normal formula: 30 + 2 * 5
+		{"Weighed tube", QuoteRequest{Weight: 2, Zone: "Express", Package: "tube"}, "shipping", 40},
+		{"No package type", QuoteRequest{Weight: 4, Zone: "International"}, "shipping", 30},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := c.Quote(tc.req)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if q.Lines[0].Code != tc.expectedCode || q.Total != tc.expectedFee {
+				t.Errorf("Expected %s line of %.2f, got %+v", tc.expectedCode, tc.expectedFee, q.Lines[0])
+			}
+		})
+	}
+
+	// The general 50kg limit still applies before package constraints
+	if _, err := c.Quote(QuoteRequest{Weight: 0, Zone: "Domestic", Package: "envelope"}); err == nil {
+		t.Error("Expected an error for a zero-weight envelope")
+	}
+}
@@ -0,0 +1,84 @@
+// peak.go
+package shipping
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// PeakSurcharge is the extra charge a zone carries during a peak period.
+// Fixed is a flat amount; Percent is a fraction of the shipping fee (0.10 = 10%).
+// Both may be set, in which case they are added together.
+type PeakSurcharge struct {
+	Fixed   float64 `json:"fixed"`
+	Percent float64 `json:"percent"`
+}
+
+// PeakPeriod is a named run of days, e.g. the December holiday peak.
+// Start and End are inclusive and compared by calendar date only.
+type PeakPeriod struct {
+	Name  string                   `json:"name"`
+	Start time.Time                `json:"start"`
+	End   time.Time                `json:"end"`
+	Zones map[string]PeakSurcharge `json:"zones"`
+}
+
+// PeakCalendar is the list of peak periods carriers have announced.
+// When periods overlap, the one added first wins.
+type PeakCalendar struct {
+	Periods []PeakPeriod `json:"periods"`
+}
+
+// Add validates p and appends it to the calendar.
+func (c *PeakCalendar) Add(p PeakPeriod) error {
+	if p.Name == "" {
+		return errors.New("peak period needs a name")
+	}
+	if dateOf(p.End).Before(dateOf(p.Start)) {
+		return fmt.Errorf("peak period %s ends before it starts", p.Name)
+	}
+	for zone, s := range p.Zones {
+		if s.Fixed < 0 || s.Percent < 0 {
+			return fmt.Errorf("peak period %s: negative surcharge for zone %s", p.Name, zone)
+		}
+	}
+
+	c.Periods = append(c.Periods, p)
+	return nil
+}
+
+// Lookup finds the peak period covering shipDate that has a surcharge for zone.
+func (c *PeakCalendar) Lookup(zone string, shipDate time.Time) (PeakPeriod, PeakSurcharge, bool) {
+	day := dateOf(shipDate)
+	for _, p := range c.Periods {
+		if day.Before(dateOf(p.Start)) || day.After(dateOf(p.End)) {
+			continue
+		}
+		if s, ok := p.Zones[zone]; ok {
+			return p, s, true
+		}
+	}
+	return PeakPeriod{}, PeakSurcharge{}, false
+}
+
+// Surcharge returns the peak surcharge line for a parcel costing fee, if any applies.
+func (c *PeakCalendar) Surcharge(zone string, shipDate time.Time, fee float64) (LineItem, bool) {
+	p, s, ok := c.Lookup(zone, shipDate)
+	if !ok {
+		return LineItem{}, false
+	}
+
+	amount := s.Fixed + fee*s.Percent
+	if amount == 0 {
+		return LineItem{}, false
+	}
+
+	return newLine("peak_surcharge", amount, p.Name), true
+}
+
+// dateOf strips the time of day so periods are matched by calendar date.
+func dateOf(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,89 @@
+// peak_test.go
+package shipping
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func day(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func holidayCalendar(t *testing.T) *PeakCalendar {
+	t.Helper()
+	cal := &PeakCalendar{}
+	err := cal.Add(PeakPeriod{
+		Name:  "Holiday",
+		Start: day(2025, time.December, 1),
+		End:   day(2025, time.December, 24),
+		Zones: map[string]PeakSurcharge{
+			"Domestic":      {Fixed: 1.50},
+			"International": {Percent: 0.10},
+			"Express":       {Fixed: 2, Percent: 0.05},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cal
+}
+
+func TestPeakCalendar_Surcharge(t *testing.T) {
+	cal := holidayCalendar(t)
+
+	testCases := []struct {
+		name     string
+		zone     string
+		shipDate time.Time
+		fee      float64
+		expected float64
+		applies  bool
+	}{
+		{"Before the peak", "Domestic", day(2025, time.November, 30), 15, 0, false},
+		{"First day (inclusive)", "Domestic", day(2025, time.December, 1), 15, 1.50, true},
+		{"Last day, late evening", "Domestic", time.Date(2025, time.December, 24, 23, 59, 0, 0, time.UTC), 15, 1.50, true},
+		{"After the peak", "Domestic", day(2025, time.December, 25), 15, 0, false},
+		{"Percentage surcharge", "International", day(2025, time.December, 10), 45, 4.50, true},
+		{"Fixed plus percentage", "Express", day(2025, time.December, 10), 80, 6, true},
+		{"Zone without surcharge", "Local", day(2025, time.December, 10), 15, 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			line, ok := cal.Surcharge(tc.zone, tc.shipDate, tc.fee)
+			if ok != tc.applies {
+				t.Fatalf("Expected applies=%v, got %v", tc.applies, ok)
+			}
+			if math.Abs(line.Amount-tc.expected) > 0.0001 {
+				t.Errorf("Expected surcharge %.2f, got %.2f", tc.expected, line.Amount)
+			}
+		})
+	}
+}
+
+func TestPeakCalendar_Add(t *testing.T) {
+	cal := &PeakCalendar{}
+
+	// A period that ends before it starts is rejected
+	err := cal.Add(PeakPeriod{Name: "Backwards", Start: day(2025, time.December, 2), End: day(2025, time.December, 1)})
+	if err == nil {
+		t.Error("Expected an error for a period ending before it starts")
+	}
+
+	// Negative surcharges are rejected
+	err = cal.Add(PeakPeriod{
+		Name:  "Negative",
+		Start: day(2025, time.December, 1),
+		End:   day(2025, time.December, 2),
+		Zones: map[string]PeakSurcharge{"Domestic": {Fixed: -1}},
+	})
+	if err == nil {
+		t.Error("Expected an error for a negative surcharge")
+	}
+
+	if len(cal.Periods) != 0 {
+		t.Errorf("Expected no periods to be stored, got %d", len(cal.Periods))
+	}
+}
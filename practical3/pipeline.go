@@ -0,0 +1,274 @@
+// pipeline.go
+package shipping
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// PricingContext is what each pricing stage reads and modifies.
+type PricingContext struct {
+	Context    context.Context
+	Request    QuoteRequest
+	Calculator *Calculator
+	// Card is the rate card this quote is priced with.
+	Card  RateCard
+	Quote *Quote
+	// Fee is the freight charge (base fee plus weight-related lines). The
+	// percentage-based surcharges, insurance and discounts are worked out
+	// from it.
+	Fee float64
+	// Package is the validated package type, if the request named one.
+	Package *PackageType
+}
+
+// PricingStage is one step of the pricing pipeline.
+type PricingStage interface {
+	Name() string
+	Apply(pc *PricingContext) error
+}
+
+type stageFunc struct {
+	name string
+	fn   func(*PricingContext) error
+}
+
+func (s stageFunc) Name() string                   { return s.name }
+func (s stageFunc) Apply(pc *PricingContext) error { return s.fn(pc) }
+
+// Stage turns a function into a named pricing stage.
+func Stage(name string, fn func(*PricingContext) error) PricingStage {
+	return stageFunc{name: name, fn: fn}
+}
+
+// DefaultStages returns the standard pipeline, in order: base, weight,
+// surcharges, insurance, discounts, emissions, tax, rounding, limits and
+// currency.
+func DefaultStages() []PricingStage {
+	return []PricingStage{
+		Stage("base", baseStage),
+		Stage("weight", weightStage),
+		Stage("surcharges", surchargeStage),
+		Stage("insurance", insuranceStage),
+		Stage("discounts", discountStage),
+		Stage("emissions", emissionsStage),
+		Stage("tax", taxStage),
+		Stage("rounding", roundingStage),
+		Stage("limits", limitStage),
+		Stage("currency", currencyStage),
+	}
+}
+
+// InsertBefore returns a copy of stages with s placed before the stage
+// called name. It panics if there is no such stage, since that is a
+// configuration mistake.
+func InsertBefore(stages []PricingStage, name string, s PricingStage) []PricingStage {
+	return insertStage(stages, name, 0, s)
+}
+
+// InsertAfter returns a copy of stages with s placed after the stage called name.
+func InsertAfter(stages []PricingStage, name string, s PricingStage) []PricingStage {
+	return insertStage(stages, name, 1, s)
+}
+
+func insertStage(stages []PricingStage, name string, offset int, s PricingStage) []PricingStage {
+	for i, existing := range stages {
+		if existing.Name() != name {
+			continue
+		}
+		out := make([]PricingStage, 0, len(stages)+1)
+		out = append(out, stages[:i+offset]...)
+		out = append(out, s)
+		return append(out, stages[i+offset:]...)
+	}
+	panic("shipping: no pricing stage named " + name)
+}
+
+// baseStage validates the parcel and adds the base fee or flat rate.
+func baseStage(pc *PricingContext) error {
+	req := pc.Request
+	base, err := pc.Card.BaseLine(req.Weight, req.Zone)
+	if err != nil {
+		return err
+	}
+
+	if req.Package != "" {
+		packages := pc.Calculator.Packages
+		if packages == nil {
+			packages = DefaultPackages
+		}
+		pt, err := packages.Validate(req.Package, req.Weight, req.Dimensions, req.Zone)
+		if err != nil {
+			return err
+		}
+		pc.Package = &pt
+		if flat, ok := pt.FlatRate(req.Zone); ok {
+			base = newLine("flat_rate", flat, pt.Name)
+		}
+	}
+
+	pc.Quote.AddLine(base)
+	pc.Fee = base.Amount
+	return nil
+}
+
+// weightStage adds the heavy parcel surcharge. Flat-rate packages are exempt.
+func weightStage(pc *PricingContext) error {
+	if pc.Package != nil {
+		if _, ok := pc.Package.FlatRate(pc.Request.Zone); ok {
+			return nil
+		}
+	}
+	if line, ok := pc.Card.HeavyLine(pc.Request.Weight); ok {
+		pc.Quote.AddLine(line)
+		pc.Fee += line.Amount
+	}
+	return nil
+}
+
+// surchargeStage adds the peak surcharge and the cash on delivery fee.
+func surchargeStage(pc *PricingContext) error {
+	c, req := pc.Calculator, pc.Request
+
+	if c.Peaks != nil {
+		if line, ok := c.Peaks.Surcharge(req.Zone, req.ShipDate, pc.Fee); ok {
+			pc.Quote.AddLine(line)
+		}
+	}
+
+	if req.COD != nil {
+		if req.ReturnOf != "" {
+			return fieldError("cod", MsgReturnCOD)
+		}
+		cod := DefaultCODPricing
+		if c.COD != nil {
+			cod = *c.COD
+		}
+		line, err := cod.Fee(req.Zone, *req.COD)
+		if err != nil {
+			return err
+		}
+		pc.Quote.AddLine(line)
+	}
+	return nil
+}
+
+// insuranceStage insures the freight charge when requested.
+func insuranceStage(pc *PricingContext) error {
+	card := pc.Card
+	if pc.Request.Insured && card.InsuranceRate > 0 {
+		pc.Quote.AddLine(card.InsuranceLine(pc.Fee))
+	}
+	return nil
+}
+
+// discountStage applies return label and volume discounts.
+func discountStage(pc *PricingContext) error {
+	c, req := pc.Calculator, pc.Request
+
+	if req.ReturnOf != "" {
+		returns := DefaultReturnPricing
+		if c.Returns != nil {
+			returns = *c.Returns
+		}
+		line, err := returns.Label(req.ReturnOf, req.Zone, pc.Fee)
+		if err != nil {
+			return err
+		}
+		pc.Quote.AddLine(line)
+	}
+
+	if c.Volume != nil {
+		shipDate := req.ShipDate
+		if shipDate.IsZero() {
+			shipDate = c.now()
+		}
+		line, ok, err := c.Volume.Discount(pc.Context, req.CustomerID, shipDate, pc.Fee)
+		if err != nil {
+			return err
+		}
+		if ok {
+			pc.Quote.AddLine(line)
+		}
+	}
+	return nil
+}
+
+// taxStage estimates customs duty and import VAT for International parcels.
+// The carbon offset is left out of the VAT base.
+func taxStage(pc *PricingContext) error {
+	c, req := pc.Calculator, pc.Request
+	if c.Tariff == nil || req.Zone != "International" || len(req.Items) == 0 {
+		return nil
+	}
+
+	lc, err := c.Tariff.Estimate(req.Destination, req.Items, taxableFee(pc.Quote))
+	if err != nil {
+		return err
+	}
+	pc.Quote.Customs = &lc
+	return nil
+}
+
+// taxableFee is the part of the quote that counts towards import VAT. A
+// carbon offset is a separate purchase, not part of the carriage.
+func taxableFee(q *Quote) float64 {
+	fee := q.Total
+	for _, line := range q.Lines {
+		if line.Code == "carbon_offset" {
+			fee -= line.Amount
+		}
+	}
+	return roundCents(fee)
+}
+
+// roundingStage rounds every line to cents and recomputes the total from
+// the rounded lines, so the lines always add up.
+func roundingStage(pc *PricingContext) error {
+	q := pc.Quote
+	q.Total = 0
+	for i := range q.Lines {
+		q.Lines[i].Amount = roundCents(q.Lines[i].Amount)
+		q.Total = roundCents(q.Total + q.Lines[i].Amount)
+	}
+	return nil
+}
+
+// currencyStage converts the quote into the customer's currency.
+func currencyStage(pc *PricingContext) error {
+	c, req, q := pc.Calculator, pc.Request, pc.Quote
+	if !pc.converting() {
+		return nil
+	}
+
+	if c.Rates == nil {
+		return fieldError("currency", MsgCurrencyNoRates, req.Currency)
+	}
+	if c.Rates.Base != q.Currency {
+		return fmt.Errorf("exchange rates are based on %s, fees on %s", c.Rates.Base, q.Currency)
+	}
+	cur, err := LookupCurrency(req.Currency)
+	if err != nil {
+		fe := fieldError("currency", MsgCurrencyUnsupported, req.Currency)
+		fe.Err = err
+		return fe
+	}
+	p, err := c.Rates.Present(*q, cur.Code)
+	if err != nil {
+		fe := fieldError("currency", MsgCurrencyNoRate, c.Rates.Base, cur.Code)
+		fe.Err = err
+		return fe
+	}
+	if c.Limits != nil {
+		c.Limits.endPresentment(p, cur, req.Zone, req.Service())
+	}
+	q.Presentment = p
+	return nil
+}
+
+// converting reports whether the customer pays in a currency other than
+// the one the quote is priced in.
+func (pc *PricingContext) converting() bool {
+	return pc.Request.Currency != "" && !strings.EqualFold(pc.Request.Currency, pc.Quote.Currency)
+}
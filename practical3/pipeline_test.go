@@ -0,0 +1,75 @@
+// pipeline_test.go
+package shipping
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCalculator_TieredCard(t *testing.T) {
+	// The tiered card with the default pipeline agrees with shippingv2
+	c := Calculator{Card: &TieredRateCard}
+
+	testCases := []struct {
+		name     string
+		weight   float64
+		zone     string
+		insured  bool
+		expected float64
+		lines    int
+	}{
+		{"Standard uninsured", 5, "Domestic", false, 5, 1},
+		{"Heavy uninsured", 20, "International", false, 27.50, 2},
+		{"Heavy insured", 20, "Express", true, 38.06, 3}, // 37.50 * 1.015 = 38.0625
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := c.Quote(QuoteRequest{Weight: tc.weight, Zone: tc.zone, Insured: tc.insured})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if q.Total != tc.expected || len(q.Lines) != tc.lines {
+				t.Errorf("Expected %.2f over %d lines, got %+v", tc.expected, tc.lines, q)
+			}
+		})
+	}
+}
+
+func TestCalculator_CustomStage(t *testing.T) {
+	// A fuel surcharge added without touching the calculator
+	fuel := Stage("fuel", func(pc *PricingContext) error {
+		pc.Quote.AddLine(LineItem{Code: "fuel", Description: "Fuel surcharge", Amount: pc.Fee * 0.033})
+		return nil
+	})
+	c := Calculator{Stages: InsertAfter(DefaultStages(), "surcharges", fuel)}
+
+	q, err := c.Quote(QuoteRequest{Weight: 10, Zone: "Domestic"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// 15.00 + 3.3% = 15.495, rounded to 15.50 by the rounding stage
+	if len(q.Lines) != 2 || q.Lines[1].Code != "fuel" || math.Abs(q.Total-15.50) > 0.001 {
+		t.Errorf("Expected a rounded fuel line, got %+v", q)
+	}
+
+	names := []string{}
+	for _, s := range c.Stages {
+		names = append(names, s.Name())
+	}
+	if names[2] != "surcharges" || names[3] != "fuel" || names[4] != "insurance" {
+		t.Errorf("Unexpected stage order: %v", names)
+	}
+
+	// The default pipeline is never modified in place
+	if len(DefaultStages()) != 10 {
+		t.Error("Expected DefaultStages to be unaffected")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for an unknown stage name")
+		}
+	}()
+	InsertBefore(DefaultStages(), "shipping", fuel)
+}
@@ -0,0 +1,205 @@
+// quote.go
+package shipping
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+)
+
+// LineItem is one priced component of a quote, such as the base fee or a surcharge.
+type LineItem struct {
+	Code        string  `json:"code"`
+	Description string  `json:"description"`
+	Amount      float64 `json:"amount"`
+	// Args are the values substituted into the description, kept so it
+	// can be rendered again in another language.
+	Args []string `json:"args,omitempty"`
+}
+
+// QuoteRequest holds everything needed to price a single parcel.
+type QuoteRequest struct {
+	Weight   float64   `json:"weight"`
+	Zone     string    `json:"zone"`
+	ShipDate time.Time `json:"ship_date"`
+	// Package is a package type code from the calculator's catalogue, and
+	// Dimensions its measurements. Both are optional.
+	Package    string      `json:"package,omitempty"`
+	Dimensions *Dimensions `json:"dimensions,omitempty"`
+	// Currency is the customer's currency. Empty means the base currency.
+	Currency string `json:"currency,omitempty"`
+	// CustomerID identifies the sender for volume pricing.
+	CustomerID string `json:"customer_id,omitempty"`
+	// Destination and Items describe the goods in an International parcel
+	// so duty and import tax can be estimated.
+	Destination string        `json:"destination,omitempty"`
+	Items       []CustomsItem `json:"items,omitempty"`
+	// COD asks for the recipient to pay on delivery.
+	COD *CODOption `json:"cod,omitempty"`
+	// ReturnOf, when set, prices a prepaid return label for that shipment.
+	ReturnOf string `json:"return_of,omitempty"`
+	// Insured adds insurance at the rate card's insurance rate.
+	Insured bool `json:"insured,omitempty"`
+	// CarbonOffset adds a fee to offset the shipment's estimated emissions.
+	CarbonOffset bool `json:"carbon_offset,omitempty"`
+}
+
+// Quote is the itemised price for a QuoteRequest. Lines and Total are in
+// the base currency; Presentment carries the converted amounts when the
+// customer pays in another currency.
+type Quote struct {
+	Currency    string       `json:"currency"`
+	Lines       []LineItem   `json:"lines"`
+	Total       float64      `json:"total"`
+	Presentment *Presentment `json:"presentment,omitempty"`
+	// Customs is the estimated duty and import VAT, payable on top of Total.
+	Customs *LandedCost `json:"customs,omitempty"`
+	// Emissions is the estimated CO2e for sustainability reporting.
+	Emissions *Emissions `json:"emissions,omitempty"`
+}
+
+// Calculator prices quote requests by running them through a pipeline of
+// pricing stages. The zero value prices with StandardRateCard, which
+// matches CalculateShippingFee, and adds no extras.
+type Calculator struct {
+	// Card holds the base rates. StandardRateCard is used when nil.
+	Card *RateCard
+	// Cards, when set, supplies the card for every quote instead of Card.
+	// Pointing it at a RateCardRegistry makes approvals and rollbacks
+	// change prices as soon as they take effect.
+	Cards RateCardSource
+	// Stages is the pricing pipeline. DefaultStages is used when nil.
+	Stages []PricingStage
+	// Peaks, when set, adds seasonal surcharges based on the ship date.
+	Peaks *PeakCalendar
+	// BaseCurrency is the currency fees are defined in. Defaults to USD.
+	BaseCurrency string
+	// Rates converts quotes into the customer's currency when it differs
+	// from the base currency.
+	Rates *ExchangeRates
+	// Volume, when set, discounts senders who ship a lot in the current period.
+	Volume *VolumePricing
+	// Tariff, when set, estimates landed cost for International parcels
+	// that declare their contents.
+	Tariff CustomsTariff
+	// COD and Returns price the optional services. DefaultCODPricing and
+	// DefaultReturnPricing are used when they are nil.
+	COD     *CODPricing
+	Returns *ReturnPricing
+	// Packages is the package type catalogue. DefaultPackages is used when nil.
+	Packages PackageCatalogue
+	// Limits, when set, enforces minimum and maximum fees and price endings.
+	// Build it with NewPricePolicy so contradictory limits are caught early.
+	Limits *PricePolicy
+	// Emissions estimates CO2e and prices carbon offsets.
+	// DefaultEmissionsModel is used when nil.
+	Emissions *EmissionsModel
+	// Observer, when set, is told about every quote for metrics.
+	Observer Observer
+	// Now is used instead of time.Now when set. It dates requests that
+	// have no ship date, such as when counting volume shipments.
+	Now func() time.Time
+}
+
+// RateCardSource supplies the rate card in effect, such as a RateCardRegistry.
+type RateCardSource interface {
+	Card(ctx context.Context) (RateCard, error)
+}
+
+// Quote prices req and returns the fee broken down into line items.
+func (c *Calculator) Quote(req QuoteRequest) (Quote, error) {
+	return c.QuoteContext(context.Background(), req)
+}
+
+// QuoteContext is Quote with a context for lookups such as shipment counts.
+func (c *Calculator) QuoteContext(ctx context.Context, req QuoteRequest) (Quote, error) {
+	card, err := c.RateCard(ctx)
+	if err != nil {
+		return Quote{}, err
+	}
+	if c.Observer == nil {
+		return c.quote(ctx, card, req)
+	}
+
+	// Unknown zones share one label so bad input cannot blow up metric cardinality
+	zone := req.Zone
+	if _, ok := card.Zones[zone]; !ok {
+		zone = "unknown"
+	}
+
+	start := time.Now()
+	q, err := c.quote(ctx, card, req)
+	c.Observer.ObserveQuote(ctx, QuoteObservation{
+		Zone:     zone,
+		Version:  card.Version,
+		Duration: time.Since(start),
+		Err:      err,
+	})
+	return q, err
+}
+
+// quote runs the pricing pipeline with card.
+func (c *Calculator) quote(ctx context.Context, card RateCard, req QuoteRequest) (Quote, error) {
+	stages := c.Stages
+	if stages == nil {
+		stages = DefaultStages()
+	}
+
+	pc := &PricingContext{
+		Context:    ctx,
+		Request:    req,
+		Calculator: c,
+		Card:       card,
+		Quote:      &Quote{Currency: c.baseCurrency()},
+	}
+	for _, stage := range stages {
+		if err := stage.Apply(pc); err != nil {
+			return Quote{}, err
+		}
+	}
+	return *pc.Quote, nil
+}
+
+// RateCard returns the card quotes are priced with right now: the one from
+// Cards when set, otherwise Card or StandardRateCard.
+func (c *Calculator) RateCard(ctx context.Context) (RateCard, error) {
+	if c.Cards != nil {
+		return c.Cards.Card(ctx)
+	}
+	return c.card(), nil
+}
+
+// card returns the configured rate card, defaulting to StandardRateCard.
+func (c *Calculator) card() RateCard {
+	if c.Card == nil {
+		return StandardRateCard
+	}
+	return *c.Card
+}
+
+func (c *Calculator) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+// baseCurrency returns the configured base currency, defaulting to USD.
+func (c *Calculator) baseCurrency() string {
+	if c.BaseCurrency == "" {
+		return "USD"
+	}
+	return strings.ToUpper(c.BaseCurrency)
+}
+
+// AddLine appends a line item and keeps the total in step.
+func (q *Quote) AddLine(line LineItem) {
+	q.Lines = append(q.Lines, line)
+	q.Total += line.Amount
+}
+
+// roundCents rounds a money amount to two decimal places.
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
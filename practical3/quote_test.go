@@ -0,0 +1,40 @@
+// quote_test.go
+package shipping
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalculator_Quote(t *testing.T) {
+	// Without a peak calendar the quote is just the base fee
+	var plain Calculator
+	q, err := plain.Quote(QuoteRequest{Weight: 10, Zone: "Domestic"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(q.Lines) != 1 || q.Total != 15 {
+		t.Errorf("Expected a single 15.00 line, got %+v", q)
+	}
+
+	// During a peak the surcharge is reported as its own line
+	c := Calculator{Peaks: holidayCalendar(t)}
+	q, err = c.Quote(QuoteRequest{Weight: 10, Zone: "International", ShipDate: time.Date(2025, time.December, 5, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(q.Lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d", len(q.Lines))
+	}
+	if q.Lines[1].Code != "peak_surcharge" || q.Lines[1].Amount != 4.50 {
+		t.Errorf("Unexpected surcharge line: %+v", q.Lines[1])
+	}
+	if q.Total != 49.50 {
+		t.Errorf("Expected total 49.50, got %.2f", q.Total)
+	}
+
+	// Validation errors from the base fee are passed through
+	if _, err := c.Quote(QuoteRequest{Weight: 0, Zone: "Domestic"}); err == nil {
+		t.Error("Expected an error for an invalid weight")
+	}
+}
@@ -0,0 +1,261 @@
+// rateadmin.go
+
+// Package rateadmin is the HTTP admin API for rate cards. Pricing staff
+// create drafts, review their diff against the active card, approve them
+// with an effective date and roll back mistakes.
+package rateadmin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"shipping"
+)
+
+// Handler serves the admin API. Routes:
+//
+//	GET  /ratecards                   revision history, oldest first
+//	POST /ratecards                   create a draft
+//	GET  /ratecards/active            the revision in effect now
+//	POST /ratecards/rollback          withdraw the active card
+//	GET  /ratecards/{version}         one revision
+//	PUT  /ratecards/{version}         edit a draft
+//	GET  /ratecards/{version}/diff    changes against the active card
+//	POST /ratecards/{version}/approve approve with an effective date
+//
+// Every change is made by the authenticated caller rather than a name in
+// the request body, so nobody who wrote or edited a card can approve it.
+// Errors are reported in the language negotiated from Accept-Language.
+type Handler struct {
+	Registry *shipping.RateCardRegistry
+	// Identity returns the authenticated user making r, or "" when there is
+	// none. UserFromContext is used when nil.
+	Identity func(r *http.Request) string
+
+	mux *http.ServeMux
+}
+
+type userKey struct{}
+
+// WithUser returns a copy of ctx carrying the authenticated user, for
+// authentication middleware in front of the admin API.
+func WithUser(ctx context.Context, user string) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// UserFromContext returns the user stored by WithUser, or "".
+func UserFromContext(ctx context.Context) string {
+	user, _ := ctx.Value(userKey{}).(string)
+	return user
+}
+
+// NewHandler returns an admin API for registry.
+func NewHandler(registry *shipping.RateCardRegistry) *Handler {
+	h := &Handler{Registry: registry, mux: http.NewServeMux()}
+	h.mux.HandleFunc("GET /ratecards", h.handleList)
+	h.mux.HandleFunc("POST /ratecards", h.handleCreate)
+	h.mux.HandleFunc("GET /ratecards/active", h.handleActive)
+	h.mux.HandleFunc("POST /ratecards/rollback", h.handleRollback)
+	h.mux.HandleFunc("GET /ratecards/{version}", h.handleGet)
+	h.mux.HandleFunc("PUT /ratecards/{version}", h.handleUpdate)
+	h.mux.HandleFunc("GET /ratecards/{version}/diff", h.handleDiff)
+	h.mux.HandleFunc("POST /ratecards/{version}/approve", h.handleApprove)
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// CreateRequest is the body of POST /ratecards.
+type CreateRequest struct {
+	Card shipping.RateCard `json:"card"`
+}
+
+// ApproveRequest is the body of POST /ratecards/{version}/approve. An
+// empty EffectiveFrom means immediately.
+type ApproveRequest struct {
+	EffectiveFrom time.Time `json:"effective_from"`
+}
+
+// DiffResponse is the body returned by GET /ratecards/{version}/diff.
+type DiffResponse struct {
+	Version string                    `json:"version"`
+	Changes []shipping.RateCardChange `json:"changes"`
+}
+
+func (h *Handler) handleList(w http.ResponseWriter, r *http.Request) {
+	revs, err := h.Registry.Store.List(r.Context())
+	if err != nil {
+		writeRegistryError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, revs)
+}
+
+func (h *Handler) handleCreate(w http.ResponseWriter, r *http.Request) {
+	user, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+	var req CreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	rev, err := h.Registry.CreateDraft(r.Context(), req.Card, user)
+	if err != nil {
+		writeRegistryError(w, r, err)
+		return
+	}
+	w.Header().Set("Location", "/ratecards/"+rev.Version())
+	writeJSON(w, http.StatusCreated, rev)
+}
+
+func (h *Handler) handleActive(w http.ResponseWriter, r *http.Request) {
+	rev, err := h.Registry.Active(r.Context(), h.now())
+	if err != nil {
+		writeRegistryError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, rev)
+}
+
+func (h *Handler) handleRollback(w http.ResponseWriter, r *http.Request) {
+	user, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+	rev, err := h.Registry.Rollback(r.Context(), user)
+	if err != nil {
+		writeRegistryError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, rev)
+}
+
+func (h *Handler) handleGet(w http.ResponseWriter, r *http.Request) {
+	rev, err := h.Registry.Store.Get(r.Context(), r.PathValue("version"))
+	if err != nil {
+		writeRegistryError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, rev)
+}
+
+func (h *Handler) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	user, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+	var card shipping.RateCard
+	if err := json.NewDecoder(r.Body).Decode(&card); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	version := r.PathValue("version")
+	if card.Version != "" && card.Version != version {
+		writeError(w, http.StatusBadRequest, "card version does not match the URL")
+		return
+	}
+	card.Version = version
+
+	rev, err := h.Registry.UpdateDraft(r.Context(), card, user)
+	if err != nil {
+		writeRegistryError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, rev)
+}
+
+func (h *Handler) handleDiff(w http.ResponseWriter, r *http.Request) {
+	version := r.PathValue("version")
+	changes, err := h.Registry.Diff(r.Context(), version)
+	if err != nil {
+		writeRegistryError(w, r, err)
+		return
+	}
+	if changes == nil {
+		changes = []shipping.RateCardChange{}
+	}
+	writeJSON(w, http.StatusOK, DiffResponse{Version: version, Changes: changes})
+}
+
+func (h *Handler) handleApprove(w http.ResponseWriter, r *http.Request) {
+	user, ok := h.user(w, r)
+	if !ok {
+		return
+	}
+	var req ApproveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	rev, err := h.Registry.Approve(r.Context(), r.PathValue("version"), user, req.EffectiveFrom)
+	if err != nil {
+		writeRegistryError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, rev)
+}
+
+// user returns the authenticated caller, writing a 401 when there is none.
+func (h *Handler) user(w http.ResponseWriter, r *http.Request) (string, bool) {
+	var user string
+	if h.Identity != nil {
+		user = h.Identity(r)
+	} else {
+		user = UserFromContext(r.Context())
+	}
+	if user == "" {
+		writeError(w, http.StatusUnauthorized, "authentication required")
+		return "", false
+	}
+	return user, true
+}
+
+func (h *Handler) now() time.Time {
+	if h.Registry.Now != nil {
+		return h.Registry.Now()
+	}
+	return time.Now()
+}
+
+// errorBody is the JSON error format used by the admin API.
+type errorBody struct {
+	Error string `json:"error"`
+	Field string `json:"field,omitempty"`
+}
+
+func writeRegistryError(w http.ResponseWriter, r *http.Request, err error) {
+	var fe *shipping.FieldError
+	switch {
+	case errors.As(err, &fe):
+		locale := shipping.Messages.NegotiateLocale(r.Header.Get("Accept-Language"))
+		w.Header().Set("Content-Language", locale)
+		writeJSON(w, http.StatusBadRequest, errorBody{Error: shipping.LocalizeError(fe, locale), Field: fe.Field})
+	case errors.Is(err, shipping.ErrRateCardNotFound), errors.Is(err, shipping.ErrNoActiveRateCard):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, shipping.ErrRateCardSelfReview):
+		writeError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, shipping.ErrRateCardExists),
+		errors.Is(err, shipping.ErrRateCardNotDraft),
+		errors.Is(err, shipping.ErrNoPreviousRateCard):
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorBody{Error: message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
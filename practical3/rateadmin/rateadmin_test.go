@@ -0,0 +1,197 @@
+// rateadmin_test.go
+package rateadmin
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"shipping"
+)
+
+func newTestHandler(now *time.Time) *Handler {
+	registry := shipping.NewRateCardRegistry(shipping.NewMemoryRateCardStore())
+	registry.Now = func() time.Time { return *now }
+	return NewHandler(registry)
+}
+
+// do sends a request as user; an empty user is unauthenticated.
+func do(t *testing.T, h http.Handler, user, method, path string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, &buf)
+	if user != "" {
+		req = req.WithContext(WithUser(req.Context(), user))
+	}
+	h.ServeHTTP(rr, req)
+	return rr
+}
+
+func card(version string, domesticBase float64) shipping.RateCard {
+	c := shipping.StandardRateCard
+	c.Version = version
+	c.Zones = map[string]shipping.ZoneRate{"Domestic": {Base: domesticBase, PerKg: 1}}
+	return c
+}
+
+func TestRateCardWorkflow(t *testing.T) {
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	h := newTestHandler(&now)
+
+	if rr := do(t, h, "alice", "GET", "/ratecards/active", nil); rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 before any approval, got %d", rr.Code)
+	}
+
+	rr := do(t, h, "alice", "POST", "/ratecards", CreateRequest{Card: card("v1", 5)})
+	if rr.Code != http.StatusCreated || rr.Header().Get("Location") != "/ratecards/v1" {
+		t.Fatalf("Expected status 201 with a Location, got %d %q", rr.Code, rr.Header().Get("Location"))
+	}
+	if rr := do(t, h, "alice", "POST", "/ratecards", CreateRequest{Card: card("v1", 5)}); rr.Code != http.StatusConflict {
+		t.Errorf("Expected status 409 for a duplicate version, got %d", rr.Code)
+	}
+
+	if rr := do(t, h, "alice", "POST", "/ratecards/v1/approve", ApproveRequest{}); rr.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403 for self-approval, got %d", rr.Code)
+	}
+	if rr := do(t, h, "bob", "POST", "/ratecards/v1/approve", ApproveRequest{}); rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rr.Code, rr.Body)
+	}
+
+	// Draft a price rise and check the diff before approving it
+	do(t, h, "alice", "POST", "/ratecards", CreateRequest{Card: card("v2", 5)})
+	if rr := do(t, h, "alice", "PUT", "/ratecards/v2", card("v2", 6)); rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rr.Code, rr.Body)
+	}
+	rr = do(t, h, "alice", "GET", "/ratecards/v2/diff", nil)
+	var diff DiffResponse
+	json.NewDecoder(rr.Body).Decode(&diff)
+	if len(diff.Changes) != 1 || diff.Changes[0].Field != "zones.Domestic.base" || diff.Changes[0].To != 6 {
+		t.Errorf("Expected one change to the Domestic base, got %+v", diff.Changes)
+	}
+
+	effective := now.Add(24 * time.Hour)
+	do(t, h, "bob", "POST", "/ratecards/v2/approve", ApproveRequest{EffectiveFrom: effective})
+	if rr := do(t, h, "alice", "PUT", "/ratecards/v2", card("v2", 7)); rr.Code != http.StatusConflict {
+		t.Errorf("Expected status 409 editing an approved card, got %d", rr.Code)
+	}
+
+	active := func() string {
+		var rev shipping.RateCardRevision
+		json.NewDecoder(do(t, h, "alice", "GET", "/ratecards/active", nil).Body).Decode(&rev)
+		return rev.Version()
+	}
+	if v := active(); v != "v1" {
+		t.Errorf("Expected v1 active before the effective date, got %s", v)
+	}
+	now = effective
+	if v := active(); v != "v2" {
+		t.Errorf("Expected v2 active from the effective date, got %s", v)
+	}
+
+	if rr := do(t, h, "carol", "POST", "/ratecards/rollback", nil); rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rr.Code, rr.Body)
+	}
+	if v := active(); v != "v1" {
+		t.Errorf("Expected v1 active after rollback, got %s", v)
+	}
+
+	var history []shipping.RateCardRevision
+	json.NewDecoder(do(t, h, "alice", "GET", "/ratecards", nil).Body).Decode(&history)
+	if len(history) != 2 || history[1].Status != shipping.RevisionRolledBack {
+		t.Errorf("Expected v2 rolled back in the history, got %+v", history)
+	}
+}
+
+func TestRateCardErrors(t *testing.T) {
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	h := newTestHandler(&now)
+
+	tests := []struct {
+		name   string
+		user   string
+		method string
+		path   string
+		body   any
+		want   int
+	}{
+		{"unknown version", "alice", "GET", "/ratecards/nope", nil, http.StatusNotFound},
+		{"invalid card", "alice", "POST", "/ratecards", CreateRequest{Card: shipping.RateCard{Version: "bad"}}, http.StatusBadRequest},
+		{"missing version", "alice", "POST", "/ratecards", CreateRequest{Card: card("", 5)}, http.StatusBadRequest},
+		{"version mismatch", "alice", "PUT", "/ratecards/a", card("b", 5), http.StatusBadRequest},
+		{"nothing to roll back", "alice", "POST", "/ratecards/rollback", nil, http.StatusNotFound},
+		{"anonymous draft", "", "POST", "/ratecards", CreateRequest{Card: card("v1", 5)}, http.StatusUnauthorized},
+		{"anonymous approval", "", "POST", "/ratecards/v1/approve", ApproveRequest{}, http.StatusUnauthorized},
+		{"anonymous edit", "", "PUT", "/ratecards/v1", card("v1", 6), http.StatusUnauthorized},
+		{"anonymous rollback", "", "POST", "/ratecards/rollback", nil, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rr := do(t, h, tt.user, tt.method, tt.path, tt.body); rr.Code != tt.want {
+				t.Errorf("Expected status %d, got %d: %s", tt.want, rr.Code, rr.Body)
+			}
+		})
+	}
+}
+
+func TestRateCardIdentityHook(t *testing.T) {
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	h := newTestHandler(&now)
+	h.Identity = func(r *http.Request) string { return r.Header.Get("X-Authenticated-User") }
+
+	send := func(user, path string, body any) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		json.NewEncoder(&buf).Encode(body)
+		req := httptest.NewRequest("POST", path, &buf)
+		req.Header.Set("X-Authenticated-User", user)
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// Names in the body are ignored; only the hook decides who is calling
+	body := map[string]any{"card": card("v1", 5), "created_by": "mallory"}
+	if rr := send("alice", "/ratecards", body); rr.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", rr.Code, rr.Body)
+	}
+	if rr := send("alice", "/ratecards/v1/approve", map[string]any{"approved_by": "bob"}); rr.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403 for self-approval, got %d", rr.Code)
+	}
+
+	rr := send("bob", "/ratecards/v1/approve", ApproveRequest{})
+	var rev shipping.RateCardRevision
+	json.NewDecoder(rr.Body).Decode(&rev)
+	if rr.Code != http.StatusOK || rev.CreatedBy != "alice" || rev.ApprovedBy != "bob" {
+		t.Errorf("Expected v1 by alice approved by bob, got %d %+v", rr.Code, rev)
+	}
+}
+
+func TestRateCardErrorsAreLocalized(t *testing.T) {
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	h := newTestHandler(&now)
+
+	body, _ := json.Marshal(CreateRequest{Card: card("", 5)})
+	req := httptest.NewRequest("POST", "/ratecards", bytes.NewReader(body))
+	req = req.WithContext(WithUser(req.Context(), "alice"))
+	req.Header.Set("Accept-Language", "de-DE, en;q=0.5")
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	var got errorBody
+	json.NewDecoder(rr.Body).Decode(&got)
+	if rr.Code != http.StatusBadRequest || got.Error != "die Tarifversion ist erforderlich" || got.Field != "version" {
+		t.Errorf("Expected a German version error, got %d %+v", rr.Code, got)
+	}
+	if lang := rr.Header().Get("Content-Language"); lang != "de" {
+		t.Errorf("Expected Content-Language de, got %q", lang)
+	}
+}
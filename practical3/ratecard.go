@@ -0,0 +1,139 @@
+// ratecard.go
+package shipping
+
+// ZoneRate is the price formula for one zone: Base plus PerKg for every kilogram.
+type ZoneRate struct {
+	Base  float64 `json:"base"`
+	PerKg float64 `json:"per_kg"`
+}
+
+// RateCard holds the numbers behind a pricing scheme so they can be
+// compared and changed as data instead of code.
+type RateCard struct {
+	Version string              `json:"version"`
+	Zones   map[string]ZoneRate `json:"zones"`
+	// MaxWeight is the heaviest parcel accepted, in kilograms.
+	MaxWeight float64 `json:"max_weight"`
+	// Parcels heavier than HeavyThreshold pay HeavySurcharge on top.
+	HeavyThreshold float64 `json:"heavy_threshold"`
+	HeavySurcharge float64 `json:"heavy_surcharge"`
+	// InsuranceRate is charged on the base fee plus heavy surcharge for insured parcels.
+	InsuranceRate float64 `json:"insurance_rate"`
+}
+
+// StandardRateCard reproduces CalculateShippingFee, with the 1.5% insurance
+// rule from the tiered calculator for insured parcels.
+var StandardRateCard = RateCard{
+	Version: "standard",
+	Zones: map[string]ZoneRate{
+		"Domestic":      {Base: 5.0, PerKg: 1.0},
+		"International": {Base: 20.0, PerKg: 2.5},
+		"Express":       {Base: 30.0, PerKg: 5.0},
+	},
+	MaxWeight:     50,
+	InsuranceRate: 0.015,
+}
+
+// TieredRateCard reproduces the tiered calculator in shippingv2.
+var TieredRateCard = RateCard{
+	Version: "tiered",
+	Zones: map[string]ZoneRate{
+		"Domestic":      {Base: 5.0},
+		"International": {Base: 20.0},
+		"Express":       {Base: 30.0},
+	},
+	MaxWeight:      50,
+	HeavyThreshold: 10,
+	HeavySurcharge: 7.50,
+	InsuranceRate:  0.015,
+}
+
+// Validate checks the card for values that would produce nonsense prices.
+// Problems are returned as a *FieldError naming the offending field.
+func (rc RateCard) Validate() error {
+	if len(rc.Zones) == 0 {
+		return fieldError("zones", MsgRateCardNoZones)
+	}
+	if rc.MaxWeight <= 0 {
+		return fieldError("max_weight", MsgRateCardMaxWeight)
+	}
+	for zone, r := range rc.Zones {
+		if r.Base < 0 || r.PerKg < 0 {
+			return fieldError("zones."+zone, MsgRateCardNegativeRate, zone)
+		}
+	}
+	for _, f := range []struct {
+		name  string
+		value float64
+	}{
+		{"heavy_threshold", rc.HeavyThreshold},
+		{"heavy_surcharge", rc.HeavySurcharge},
+		{"insurance_rate", rc.InsuranceRate},
+	} {
+		if f.value < 0 {
+			return fieldError(f.name, MsgRateCardNegativeSurcharge)
+		}
+	}
+	return nil
+}
+
+// Lines prices a parcel and returns the base fee, heavy surcharge and
+// insurance as separate line items.
+func (rc RateCard) Lines(weight float64, zone string, insured bool) ([]LineItem, error) {
+	base, err := rc.BaseLine(weight, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := []LineItem{base}
+	subTotal := base.Amount
+
+	if heavy, ok := rc.HeavyLine(weight); ok {
+		lines = append(lines, heavy)
+		subTotal += heavy.Amount
+	}
+
+	if insured && rc.InsuranceRate > 0 {
+		lines = append(lines, rc.InsuranceLine(subTotal))
+	}
+	return lines, nil
+}
+
+// BaseLine validates weight and zone and returns the zone's base fee plus
+// the per-kilogram charge.
+func (rc RateCard) BaseLine(weight float64, zone string) (LineItem, error) {
+	if weight <= 0 || weight > rc.MaxWeight {
+		return LineItem{}, fieldError("weight", MsgInvalidWeight)
+	}
+	rate, ok := rc.Zones[zone]
+	if !ok {
+		return LineItem{}, fieldError("zone", MsgInvalidZone, zone)
+	}
+	return newLine("shipping", rate.Base+weight*rate.PerKg), nil
+}
+
+// HeavyLine returns the heavy parcel surcharge, if weight attracts one.
+func (rc RateCard) HeavyLine(weight float64) (LineItem, bool) {
+	if rc.HeavySurcharge <= 0 || weight <= rc.HeavyThreshold {
+		return LineItem{}, false
+	}
+	return newLine("heavy_surcharge", rc.HeavySurcharge), true
+}
+
+// InsuranceLine returns the insurance charge on a subtotal.
+func (rc RateCard) InsuranceLine(subTotal float64) LineItem {
+	return newLine("insurance", subTotal*rc.InsuranceRate)
+}
+
+// Price returns the total of Lines.
+func (rc RateCard) Price(weight float64, zone string, insured bool) (float64, error) {
+	lines, err := rc.Lines(weight, zone, insured)
+	if err != nil {
+		return 0, err
+	}
+	total := 0.0
+	for _, line := range lines {
+		total += line.Amount
+	}
+	return total, nil
+}
@@ -0,0 +1,58 @@
+// ratecard_test.go
+package shipping
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRateCard_MatchesCalculators(t *testing.T) {
+	// The standard card must agree with CalculateShippingFee everywhere
+	for _, zone := range []string{"Domestic", "International", "Express", "Local"} {
+		for _, weight := range []float64{-1, 0, 0.1, 10, 10.1, 50, 50.1} {
+			want, wantErr := CalculateShippingFee(weight, zone)
+			got, gotErr := StandardRateCard.Price(weight, zone, false)
+			if (wantErr == nil) != (gotErr == nil) || math.Abs(want-got) > 0.0001 {
+				t.Errorf("%s %.1fkg: expected %.2f (%v), got %.2f (%v)", zone, weight, want, wantErr, got, gotErr)
+			}
+		}
+	}
+
+	// The tiered card follows the shippingv2 rules
+	testCases := []struct {
+		name     string
+		weight   float64
+		zone     string
+		insured  bool
+		expected float64
+	}{
+		{"Standard weight", 10, "Domestic", false, 5},
+		{"Heavy weight", 10.1, "International", false, 27.50},
+		{"Heavy weight insured", 20, "Express", true, 37.50 * 1.015},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := TieredRateCard.Price(tc.weight, tc.zone, tc.insured)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if math.Abs(got-tc.expected) > 0.0001 {
+				t.Errorf("Expected %.4f, got %.4f", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestRateCard_Validate(t *testing.T) {
+	if err := StandardRateCard.Validate(); err != nil {
+		t.Errorf("Expected the standard card to be valid, got %v", err)
+	}
+	bad := TieredRateCard
+	bad.Zones = map[string]ZoneRate{"Domestic": {Base: -5}}
+	if err := bad.Validate(); err == nil {
+		t.Error("Expected an error for a negative base fee")
+	}
+	if err := (RateCard{Zones: StandardRateCard.Zones}).Validate(); err == nil {
+		t.Error("Expected an error for a missing max weight")
+	}
+}
@@ -0,0 +1,395 @@
+// ratehistory.go
+package shipping
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	ErrRateCardNotFound   = errors.New("rate card not found")
+	ErrRateCardExists     = errors.New("rate card version already exists")
+	ErrRateCardNotDraft   = errors.New("rate card is not a draft")
+	ErrRateCardSelfReview = errors.New("rate card must be approved by someone who did not write or edit it")
+	ErrNoActiveRateCard   = errors.New("no active rate card")
+	ErrNoPreviousRateCard = errors.New("no previous rate card to roll back to")
+)
+
+// RevisionStatus is where a rate card is in its review lifecycle.
+type RevisionStatus string
+
+const (
+	// RevisionDraft cards can still be edited and never price anything.
+	RevisionDraft RevisionStatus = "draft"
+	// RevisionApproved cards price parcels from their effective date.
+	RevisionApproved RevisionStatus = "approved"
+	// RevisionRolledBack cards were withdrawn after approval.
+	RevisionRolledBack RevisionStatus = "rolled_back"
+)
+
+// RateCardRevision is one version of the rate card and its review history.
+type RateCardRevision struct {
+	Card      RateCard       `json:"card"`
+	Status    RevisionStatus `json:"status"`
+	CreatedBy string         `json:"created_by"`
+	CreatedAt time.Time      `json:"created_at"`
+	// EditedBy lists everyone other than the author who changed the
+	// draft. Like the author, they cannot approve it.
+	EditedBy []string `json:"edited_by,omitempty"`
+	// ApprovedBy, ApprovedAt and EffectiveFrom are set on approval.
+	ApprovedBy    string    `json:"approved_by,omitempty"`
+	ApprovedAt    time.Time `json:"approved_at,omitzero"`
+	EffectiveFrom time.Time `json:"effective_from,omitzero"`
+	// RolledBackBy and RolledBackAt are set when the card is withdrawn.
+	RolledBackBy string    `json:"rolled_back_by,omitempty"`
+	RolledBackAt time.Time `json:"rolled_back_at,omitzero"`
+}
+
+// wroteBy reports whether user authored or edited the card.
+func (r RateCardRevision) wroteBy(user string) bool {
+	if r.CreatedBy == user {
+		return true
+	}
+	for _, editor := range r.EditedBy {
+		if editor == user {
+			return true
+		}
+	}
+	return false
+}
+
+// Version returns the card's version, which identifies the revision.
+func (r RateCardRevision) Version() string {
+	return r.Card.Version
+}
+
+// RateCardStore persists rate card revisions.
+type RateCardStore interface {
+	// Get returns ErrRateCardNotFound when version is unknown.
+	Get(ctx context.Context, version string) (RateCardRevision, error)
+	// Save inserts or replaces the revision with the same version.
+	Save(ctx context.Context, rev RateCardRevision) error
+	// List returns every revision, oldest first.
+	List(ctx context.Context) ([]RateCardRevision, error)
+}
+
+// MemoryRateCardStore keeps revisions in memory. It is safe for concurrent use.
+type MemoryRateCardStore struct {
+	mu        sync.Mutex
+	revisions map[string]RateCardRevision
+}
+
+// NewMemoryRateCardStore returns an empty store.
+func NewMemoryRateCardStore() *MemoryRateCardStore {
+	return &MemoryRateCardStore{revisions: make(map[string]RateCardRevision)}
+}
+
+// Get implements RateCardStore.
+func (m *MemoryRateCardStore) Get(ctx context.Context, version string) (RateCardRevision, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rev, ok := m.revisions[version]
+	if !ok {
+		return RateCardRevision{}, fmt.Errorf("%w: %s", ErrRateCardNotFound, version)
+	}
+	return rev, nil
+}
+
+// Save implements RateCardStore.
+func (m *MemoryRateCardStore) Save(ctx context.Context, rev RateCardRevision) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.revisions[rev.Version()] = rev
+	return nil
+}
+
+// List implements RateCardStore.
+func (m *MemoryRateCardStore) List(ctx context.Context) ([]RateCardRevision, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	revs := make([]RateCardRevision, 0, len(m.revisions))
+	for _, rev := range m.revisions {
+		revs = append(revs, rev)
+	}
+	sort.Slice(revs, func(i, j int) bool {
+		if !revs[i].CreatedAt.Equal(revs[j].CreatedAt) {
+			return revs[i].CreatedAt.Before(revs[j].CreatedAt)
+		}
+		return revs[i].Version() < revs[j].Version()
+	})
+	return revs, nil
+}
+
+// RateCardRegistry runs the draft, approve, activate and roll back
+// workflow on top of a store. The card in effect at any moment is the
+// approved card with the latest effective date not after that moment, so
+// an approval with a future date schedules the change and a rollback
+// brings the previous card back.
+type RateCardRegistry struct {
+	Store RateCardStore
+	// Now returns the current time. time.Now is used when nil.
+	Now func() time.Time
+
+	// mu serialises read-modify-write cycles against the store.
+	mu sync.Mutex
+}
+
+// NewRateCardRegistry returns a registry backed by store.
+func NewRateCardRegistry(store RateCardStore) *RateCardRegistry {
+	return &RateCardRegistry{Store: store}
+}
+
+func (r *RateCardRegistry) now() time.Time {
+	if r.Now != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
+
+// CreateDraft stores card as a new draft written by author. The author is
+// required so that Approve can stop them reviewing their own card.
+func (r *RateCardRegistry) CreateDraft(ctx context.Context, card RateCard, author string) (RateCardRevision, error) {
+	if author == "" {
+		return RateCardRevision{}, fieldError("created_by", MsgRateCardAuthorRequired)
+	}
+	if card.Version == "" {
+		return RateCardRevision{}, fieldError("version", MsgRateCardVersionRequired)
+	}
+	if err := card.Validate(); err != nil {
+		return RateCardRevision{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := r.Store.Get(ctx, card.Version); err == nil {
+		return RateCardRevision{}, fmt.Errorf("%w: %s", ErrRateCardExists, card.Version)
+	} else if !errors.Is(err, ErrRateCardNotFound) {
+		return RateCardRevision{}, err
+	}
+
+	rev := RateCardRevision{Card: card, Status: RevisionDraft, CreatedBy: author, CreatedAt: r.now()}
+	if err := r.Store.Save(ctx, rev); err != nil {
+		return RateCardRevision{}, err
+	}
+	return rev, nil
+}
+
+// UpdateDraft replaces the rates of a draft on behalf of editor, who is
+// then barred from approving it. Approved cards are immutable.
+func (r *RateCardRegistry) UpdateDraft(ctx context.Context, card RateCard, editor string) (RateCardRevision, error) {
+	if editor == "" {
+		return RateCardRevision{}, fieldError("edited_by", MsgRateCardEditorRequired)
+	}
+	if err := card.Validate(); err != nil {
+		return RateCardRevision{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rev, err := r.Store.Get(ctx, card.Version)
+	if err != nil {
+		return RateCardRevision{}, err
+	}
+	if rev.Status != RevisionDraft {
+		return RateCardRevision{}, fmt.Errorf("%w: %s is %s", ErrRateCardNotDraft, card.Version, rev.Status)
+	}
+	rev.Card = card
+	if !rev.wroteBy(editor) {
+		rev.EditedBy = append(rev.EditedBy, editor)
+	}
+	if err := r.Store.Save(ctx, rev); err != nil {
+		return RateCardRevision{}, err
+	}
+	return rev, nil
+}
+
+// Approve approves a draft to take effect at effectiveFrom. A zero
+// effectiveFrom means now; dates in the past are rejected so approved
+// prices are never applied retroactively.
+func (r *RateCardRegistry) Approve(ctx context.Context, version, approver string, effectiveFrom time.Time) (RateCardRevision, error) {
+	if approver == "" {
+		return RateCardRevision{}, fieldError("approved_by", MsgRateCardApproverRequired)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rev, err := r.Store.Get(ctx, version)
+	if err != nil {
+		return RateCardRevision{}, err
+	}
+	if rev.Status != RevisionDraft {
+		return RateCardRevision{}, fmt.Errorf("%w: %s is %s", ErrRateCardNotDraft, version, rev.Status)
+	}
+	if rev.wroteBy(approver) {
+		return RateCardRevision{}, ErrRateCardSelfReview
+	}
+
+	now := r.now()
+	if effectiveFrom.IsZero() {
+		effectiveFrom = now
+	}
+	if effectiveFrom.Before(now) {
+		return RateCardRevision{}, fieldError("effective_from", MsgRateCardEffectivePast)
+	}
+
+	rev.Status = RevisionApproved
+	rev.ApprovedBy = approver
+	rev.ApprovedAt = now
+	rev.EffectiveFrom = effectiveFrom
+	if err := r.Store.Save(ctx, rev); err != nil {
+		return RateCardRevision{}, err
+	}
+	return rev, nil
+}
+
+// Active returns the revision in effect at t.
+func (r *RateCardRegistry) Active(ctx context.Context, t time.Time) (RateCardRevision, error) {
+	revs, err := r.Store.List(ctx)
+	if err != nil {
+		return RateCardRevision{}, err
+	}
+	active, _, ok := activeAt(revs, t)
+	if !ok {
+		return RateCardRevision{}, ErrNoActiveRateCard
+	}
+	return active, nil
+}
+
+// Card returns the rate card in effect now, for wiring into a Calculator.
+func (r *RateCardRegistry) Card(ctx context.Context) (RateCard, error) {
+	rev, err := r.Active(ctx, r.now())
+	if err != nil {
+		return RateCard{}, err
+	}
+	return rev.Card, nil
+}
+
+// Rollback withdraws the card in effect now on behalf of user, so the
+// card it replaced is in effect again. It returns the reinstated revision.
+func (r *RateCardRegistry) Rollback(ctx context.Context, user string) (RateCardRevision, error) {
+	if user == "" {
+		return RateCardRevision{}, fieldError("rolled_back_by", MsgRateCardRollbackBy)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	revs, err := r.Store.List(ctx)
+	if err != nil {
+		return RateCardRevision{}, err
+	}
+
+	now := r.now()
+	active, previous, ok := activeAt(revs, now)
+	if !ok {
+		return RateCardRevision{}, ErrNoActiveRateCard
+	}
+	if previous == nil {
+		return RateCardRevision{}, ErrNoPreviousRateCard
+	}
+
+	active.Status = RevisionRolledBack
+	active.RolledBackBy = user
+	active.RolledBackAt = now
+	if err := r.Store.Save(ctx, active); err != nil {
+		return RateCardRevision{}, err
+	}
+	return *previous, nil
+}
+
+// Diff compares the draft or approved card version against the card in
+// effect now.
+func (r *RateCardRegistry) Diff(ctx context.Context, version string) ([]RateCardChange, error) {
+	rev, err := r.Store.Get(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	active, err := r.Active(ctx, r.now())
+	if err != nil {
+		if errors.Is(err, ErrNoActiveRateCard) {
+			return DiffRateCards(RateCard{}, rev.Card), nil
+		}
+		return nil, err
+	}
+	return DiffRateCards(active.Card, rev.Card), nil
+}
+
+// activeAt finds the approved revision in effect at t and the one in
+// effect before it, if any.
+func activeAt(revs []RateCardRevision, t time.Time) (RateCardRevision, *RateCardRevision, bool) {
+	var live []RateCardRevision
+	for _, rev := range revs {
+		if rev.Status == RevisionApproved && !rev.EffectiveFrom.After(t) {
+			live = append(live, rev)
+		}
+	}
+	if len(live) == 0 {
+		return RateCardRevision{}, nil, false
+	}
+	sort.SliceStable(live, func(i, j int) bool {
+		if !live[i].EffectiveFrom.Equal(live[j].EffectiveFrom) {
+			return live[i].EffectiveFrom.Before(live[j].EffectiveFrom)
+		}
+		return live[i].ApprovedAt.Before(live[j].ApprovedAt)
+	})
+
+	active := live[len(live)-1]
+	if len(live) == 1 {
+		return active, nil, true
+	}
+	previous := live[len(live)-2]
+	return active, &previous, true
+}
+
+// RateCardChange is one number that differs between two rate cards.
+// From is zero for added fields and To is zero for removed ones.
+type RateCardChange struct {
+	Field  string  `json:"field"`
+	Change string  `json:"change"`
+	From   float64 `json:"from"`
+	To     float64 `json:"to"`
+}
+
+// DiffRateCards lists the differences between two cards, zones in
+// alphabetical order followed by the card-wide settings.
+func DiffRateCards(from, to RateCard) []RateCardChange {
+	var changes []RateCardChange
+	add := func(field string, a, b float64, inFrom, inTo bool) {
+		switch {
+		case !inFrom:
+			changes = append(changes, RateCardChange{Field: field, Change: "added", To: b})
+		case !inTo:
+			changes = append(changes, RateCardChange{Field: field, Change: "removed", From: a})
+		case a != b:
+			changes = append(changes, RateCardChange{Field: field, Change: "changed", From: a, To: b})
+		}
+	}
+
+	zones := make(map[string]bool)
+	for z := range from.Zones {
+		zones[z] = true
+	}
+	for z := range to.Zones {
+		zones[z] = true
+	}
+	names := make([]string, 0, len(zones))
+	for z := range zones {
+		names = append(names, z)
+	}
+	sort.Strings(names)
+
+	for _, z := range names {
+		a, inFrom := from.Zones[z]
+		b, inTo := to.Zones[z]
+		add("zones."+z+".base", a.Base, b.Base, inFrom, inTo)
+		add("zones."+z+".per_kg", a.PerKg, b.PerKg, inFrom, inTo)
+	}
+	add("max_weight", from.MaxWeight, to.MaxWeight, true, true)
+	add("heavy_threshold", from.HeavyThreshold, to.HeavyThreshold, true, true)
+	add("heavy_surcharge", from.HeavySurcharge, to.HeavySurcharge, true, true)
+	add("insurance_rate", from.InsuranceRate, to.InsuranceRate, true, true)
+	return changes
+}
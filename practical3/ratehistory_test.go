@@ -0,0 +1,211 @@
+// ratehistory_test.go
+package shipping
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestRegistry(now *time.Time) *RateCardRegistry {
+	r := NewRateCardRegistry(NewMemoryRateCardStore())
+	r.Now = func() time.Time { return *now }
+	return r
+}
+
+func cardVersion(version string, domesticBase float64) RateCard {
+	card := StandardRateCard
+	card.Version = version
+	card.Zones = map[string]ZoneRate{
+		"Domestic":      {Base: domesticBase, PerKg: 1},
+		"International": {Base: 20, PerKg: 2.5},
+	}
+	return card
+}
+
+func TestRateCardLifecycle(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	r := newTestRegistry(&now)
+
+	if _, err := r.Active(ctx, now); !errors.Is(err, ErrNoActiveRateCard) {
+		t.Fatalf("Expected no active card, got %v", err)
+	}
+
+	if _, err := r.CreateDraft(ctx, cardVersion("2025-03", 5), "alice"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := r.CreateDraft(ctx, cardVersion("2025-03", 6), "alice"); !errors.Is(err, ErrRateCardExists) {
+		t.Errorf("Expected ErrRateCardExists, got %v", err)
+	}
+	if _, err := r.Approve(ctx, "2025-03", "alice", time.Time{}); !errors.Is(err, ErrRateCardSelfReview) {
+		t.Errorf("Expected ErrRateCardSelfReview, got %v", err)
+	}
+	if _, err := r.Approve(ctx, "2025-03", "bob", time.Time{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := r.UpdateDraft(ctx, cardVersion("2025-03", 7), "alice"); !errors.Is(err, ErrRateCardNotDraft) {
+		t.Errorf("Expected approved cards to be immutable, got %v", err)
+	}
+
+	// A second card scheduled for next month
+	effective := now.AddDate(0, 1, 0)
+	r.CreateDraft(ctx, cardVersion("2025-04", 6), "alice")
+	if _, err := r.Approve(ctx, "2025-04", "bob", effective); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		at   time.Time
+		want string
+	}{
+		{now, "2025-03"},
+		{effective.Add(-time.Second), "2025-03"},
+		{effective, "2025-04"},
+	}
+	for _, tt := range tests {
+		rev, err := r.Active(ctx, tt.at)
+		if err != nil || rev.Version() != tt.want {
+			t.Errorf("Expected %s active at %v, got %s (%v)", tt.want, tt.at, rev.Version(), err)
+		}
+	}
+
+	// Roll back once the new card is live
+	now = effective.Add(time.Hour)
+	rev, err := r.Rollback(ctx, "carol")
+	if err != nil || rev.Version() != "2025-03" {
+		t.Fatalf("Expected rollback to 2025-03, got %s (%v)", rev.Version(), err)
+	}
+	card, _ := r.Card(ctx)
+	if card.Version != "2025-03" {
+		t.Errorf("Expected 2025-03 in effect, got %s", card.Version)
+	}
+	withdrawn, _ := r.Store.Get(ctx, "2025-04")
+	if withdrawn.Status != RevisionRolledBack || withdrawn.RolledBackBy != "carol" {
+		t.Errorf("Expected 2025-04 rolled back by carol, got %s by %q", withdrawn.Status, withdrawn.RolledBackBy)
+	}
+	if _, err := r.Rollback(ctx, ""); !IsValidation(err) {
+		t.Errorf("Expected an anonymous rollback to be rejected, got %v", err)
+	}
+	if _, err := r.Rollback(ctx, "carol"); !errors.Is(err, ErrNoPreviousRateCard) {
+		t.Errorf("Expected ErrNoPreviousRateCard, got %v", err)
+	}
+}
+
+func TestRateCardApproveValidation(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	r := newTestRegistry(&now)
+	r.CreateDraft(ctx, cardVersion("v1", 5), "alice")
+
+	if _, err := r.Approve(ctx, "v1", "bob", now.Add(-time.Hour)); !IsValidation(err) {
+		t.Errorf("Expected backdated approval to be rejected, got %v", err)
+	}
+	if _, err := r.Approve(ctx, "v1", "", time.Time{}); !IsValidation(err) {
+		t.Errorf("Expected a missing approver to be rejected, got %v", err)
+	}
+	if _, err := r.Approve(ctx, "v9", "bob", time.Time{}); !errors.Is(err, ErrRateCardNotFound) {
+		t.Errorf("Expected ErrRateCardNotFound, got %v", err)
+	}
+
+	invalid := cardVersion("v2", 5)
+	invalid.MaxWeight = 0
+	var fe *FieldError
+	if _, err := r.CreateDraft(ctx, invalid, "alice"); !errors.As(err, &fe) || fe.Field != "max_weight" || fe.Code != MsgRateCardMaxWeight {
+		t.Errorf("Expected a localizable max_weight error, got %v", err)
+	}
+
+	// Without an author, anyone could approve the draft
+	if _, err := r.CreateDraft(ctx, cardVersion("v3", 5), ""); !errors.As(err, &fe) || fe.Code != MsgRateCardAuthorRequired {
+		t.Errorf("Expected a missing author to be rejected, got %v", err)
+	}
+}
+
+func TestDiffRateCards(t *testing.T) {
+	from := cardVersion("a", 5)
+	to := cardVersion("b", 6)
+	delete(to.Zones, "International")
+	to.Zones["Express"] = ZoneRate{Base: 30, PerKg: 5}
+	to.InsuranceRate = 0.02
+
+	want := []RateCardChange{
+		{Field: "zones.Domestic.base", Change: "changed", From: 5, To: 6},
+		{Field: "zones.Express.base", Change: "added", To: 30},
+		{Field: "zones.Express.per_kg", Change: "added", To: 5},
+		{Field: "zones.International.base", Change: "removed", From: 20},
+		{Field: "zones.International.per_kg", Change: "removed", From: 2.5},
+		{Field: "insurance_rate", Change: "changed", From: 0.015, To: 0.02},
+	}
+
+	got := DiffRateCards(from, to)
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d changes, got %d: %v", len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected %+v, got %+v", want[i], got[i])
+		}
+	}
+	if d := DiffRateCards(from, from); len(d) != 0 {
+		t.Errorf("Expected no changes, got %v", d)
+	}
+}
+
+func TestRateCardEditorsCannotApprove(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	r := newTestRegistry(&now)
+	r.CreateDraft(ctx, cardVersion("v1", 5), "alice")
+
+	if _, err := r.UpdateDraft(ctx, cardVersion("v1", 6), ""); !IsValidation(err) {
+		t.Errorf("Expected an anonymous edit to be rejected, got %v", err)
+	}
+	rev, err := r.UpdateDraft(ctx, cardVersion("v1", 6), "bob")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(rev.EditedBy) != 1 || rev.EditedBy[0] != "bob" {
+		t.Errorf("Expected bob recorded as an editor, got %v", rev.EditedBy)
+	}
+
+	// bob changed the rates, so he cannot be the one to review them
+	if _, err := r.Approve(ctx, "v1", "bob", time.Time{}); !errors.Is(err, ErrRateCardSelfReview) {
+		t.Errorf("Expected ErrRateCardSelfReview for an editor, got %v", err)
+	}
+	if _, err := r.Approve(ctx, "v1", "carol", time.Time{}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestCalculatorUsesRegistryCard(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	r := newTestRegistry(&now)
+	c := &Calculator{Cards: r}
+
+	if _, err := c.Quote(QuoteRequest{Weight: 1, Zone: "Domestic"}); !errors.Is(err, ErrNoActiveRateCard) {
+		t.Errorf("Expected ErrNoActiveRateCard before any approval, got %v", err)
+	}
+
+	r.CreateDraft(ctx, cardVersion("v1", 5), "alice")
+	r.Approve(ctx, "v1", "bob", time.Time{})
+	r.CreateDraft(ctx, cardVersion("v2", 8), "alice")
+	r.Approve(ctx, "v2", "bob", time.Time{})
+
+	total := func() float64 {
+		q, err := c.Quote(QuoteRequest{Weight: 1, Zone: "Domestic"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return q.Lines[0].Amount
+	}
+	// Base plus 1.00 per kg
+	if got := total(); got != 9 {
+		t.Errorf("Expected the v2 fee of 9.00, got %.2f", got)
+	}
+	r.Rollback(ctx, "carol")
+	if got := total(); got != 6 {
+		t.Errorf("Expected the v1 fee of 6.00 after rollback, got %.2f", got)
+	}
+}
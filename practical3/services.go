@@ -0,0 +1,93 @@
+// services.go
+package shipping
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CODOption asks the carrier to collect Amount from the recipient on delivery.
+type CODOption struct {
+	Amount float64 `json:"amount"`
+}
+
+// CODPricing is the fee charged for cash on delivery: Percent of the
+// collected amount, but never less than Minimum nor more than Cap.
+type CODPricing struct {
+	Percent float64
+	Minimum float64
+	Cap     float64
+	// MaxAmount is the most a courier may collect. Zero means no limit.
+	MaxAmount float64
+}
+
+// DefaultCODPricing is used when a Calculator has no COD pricing configured.
+var DefaultCODPricing = CODPricing{Percent: 0.02, Minimum: 2.00, Cap: 25.00, MaxAmount: 5000}
+
+// Fee returns the COD line item for opt being shipped to zone.
+func (p CODPricing) Fee(zone string, opt CODOption) (LineItem, error) {
+	if zone == "International" {
+		return LineItem{}, fieldError("zone", MsgCODInternational)
+	}
+	if opt.Amount <= 0 {
+		return LineItem{}, fieldError("cod.amount", MsgCODAmountInvalid)
+	}
+	if p.MaxAmount > 0 && opt.Amount > p.MaxAmount {
+		return LineItem{}, fieldError("cod.amount", MsgCODAmountLimit, p.MaxAmount)
+	}
+
+	fee := opt.Amount * p.Percent
+	if fee < p.Minimum {
+		fee = p.Minimum
+	}
+	if p.Cap > 0 && fee > p.Cap {
+		fee = p.Cap
+	}
+	return newLine("cod_fee", fee), nil
+}
+
+// ShipmentLookup finds a previously booked shipment by tracking number.
+type ShipmentLookup interface {
+	Shipment(trackingNumber string) (*Shipment, error)
+}
+
+// ReturnPricing discounts prepaid return labels by Discount (0.5 = 50%).
+type ReturnPricing struct {
+	Discount float64
+	// Shipments is used to check that the original shipment exists and
+	// has reached the recipient. Return labels are refused without it.
+	Shipments ShipmentLookup
+}
+
+// DefaultReturnPricing is used when a Calculator has no return pricing
+// configured. It has no ShipmentLookup, so it refuses every return label.
+var DefaultReturnPricing = ReturnPricing{Discount: 0.5}
+
+// Label validates a return of the shipment trackingNumber and returns the
+// discount line for a reverse shipment costing fee.
+func (p ReturnPricing) Label(trackingNumber, zone string, fee float64) (LineItem, error) {
+	if !ValidTrackingNumber(trackingNumber) {
+		return LineItem{}, fieldError("return_of", MsgReturnInvalidTracking, trackingNumber)
+	}
+	if p.Shipments == nil {
+		return LineItem{}, fieldError("return_of", MsgReturnUnavailable)
+	}
+
+	original, err := p.Shipments.Shipment(trackingNumber)
+	if err != nil {
+		if errors.Is(err, ErrShipmentNotFound) {
+			fe := fieldError("return_of", MsgReturnNotFound, trackingNumber)
+			fe.Err = err
+			return LineItem{}, fe
+		}
+		return LineItem{}, fmt.Errorf("return label for %s: %w", trackingNumber, err)
+	}
+	if original.Status != StatusDelivered && original.Status != StatusException {
+		return LineItem{}, fieldError("return_of", MsgReturnNotReturnable, trackingNumber, original.Status)
+	}
+	if original.Request.Zone != zone {
+		return LineItem{}, fieldError("zone", MsgReturnZoneMismatch, original.Request.Zone)
+	}
+
+	return newLine("return_discount", -fee*p.Discount, trackingNumber), nil
+}
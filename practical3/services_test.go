@@ -0,0 +1,98 @@
+// services_test.go
+package shipping
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCODPricing_Fee(t *testing.T) {
+	testCases := []struct {
+		name        string
+		zone        string
+		amount      float64
+		expectedFee float64
+		expectError bool
+	}{
+		{"Minimum applies", "Domestic", 50, 2.00, false},   // 2% of 50 is 1.00
+		{"Percentage applies", "Domestic", 500, 10, false}, // 2% of 500
+		{"Cap applies", "Express", 2000, 25, false},        // 2% of 2000 is 40
+		{"Zero amount", "Domestic", 0, 0, true},
+		{"Over the collection limit", "Domestic", 5000.01, 0, true},
+		{"International not offered", "International", 100, 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			line, err := DefaultCODPricing.Fee(tc.zone, CODOption{Amount: tc.amount})
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("Expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if line.Amount != tc.expectedFee {
+				t.Errorf("Expected fee %.2f, got %.2f", tc.expectedFee, line.Amount)
+			}
+		})
+	}
+}
+
+func TestCalculator_QuoteServices(t *testing.T) {
+	carrier := NewCalculatorCarrier(&Calculator{}, NewTrackingGenerator("SH", "BT", 1))
+	booking, err := carrier.Book(context.Background(), QuoteRequest{Weight: 10, Zone: "Domestic"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := Calculator{Returns: &ReturnPricing{Discount: 0.4, Shipments: carrier}}
+	returnReq := QuoteRequest{Weight: 10, Zone: "Domestic", ReturnOf: booking.TrackingNumber}
+
+	// Without a shipment lookup the original cannot be checked
+	var fe *FieldError
+	if _, err := (&Calculator{}).Quote(returnReq); !errors.As(err, &fe) || fe.Code != MsgReturnUnavailable {
+		t.Errorf("Expected %s from the default return pricing, got %v", MsgReturnUnavailable, err)
+	}
+
+	// The original has not been delivered yet
+	if _, err := c.Quote(returnReq); err == nil {
+		t.Error("Expected an error returning an undelivered shipment")
+	}
+
+	for _, status := range []ShipmentStatus{StatusPickedUp, StatusInTransit, StatusOutForDelivery, StatusDelivered} {
+		if err := carrier.Update(booking.TrackingNumber, status, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 15.00 less 40%
+	q, err := c.Quote(returnReq)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if q.Total != 9 || q.Lines[1].Code != "return_discount" {
+		t.Errorf("Expected a 9.00 return label, got %+v", q)
+	}
+
+	// Returns must travel back through the same zone and cannot be COD
+	if _, err := c.Quote(QuoteRequest{Weight: 10, Zone: "Express", ReturnOf: booking.TrackingNumber}); err == nil {
+		t.Error("Expected an error for a return in a different zone")
+	}
+	returnReq.COD = &CODOption{Amount: 10}
+	if _, err := c.Quote(returnReq); err == nil {
+		t.Error("Expected an error for a COD return label")
+	}
+
+	// COD adds its own line
+	q, err = c.Quote(QuoteRequest{Weight: 10, Zone: "Domestic", COD: &CODOption{Amount: 500}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if q.Total != 25 || q.Lines[1].Code != "cod_fee" {
+		t.Errorf("Expected 15.00 + 10.00 COD fee, got %+v", q)
+	}
+}
@@ -0,0 +1,185 @@
+// shipment.go
+package shipping
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ShipmentStatus is a stage in a shipment's lifecycle.
+type ShipmentStatus string
+
+const (
+	StatusCreated        ShipmentStatus = "created"
+	StatusPickedUp       ShipmentStatus = "picked_up"
+	StatusInTransit      ShipmentStatus = "in_transit"
+	StatusOutForDelivery ShipmentStatus = "out_for_delivery"
+	StatusDelivered      ShipmentStatus = "delivered"
+	StatusException      ShipmentStatus = "exception"
+	StatusReturned       ShipmentStatus = "returned"
+)
+
+// transitions lists the statuses each status may move to.
+// Delivered and returned are final.
+var transitions = map[ShipmentStatus][]ShipmentStatus{
+	StatusCreated:        {StatusPickedUp, StatusException},
+	StatusPickedUp:       {StatusInTransit, StatusException},
+	StatusInTransit:      {StatusOutForDelivery, StatusException},
+	StatusOutForDelivery: {StatusDelivered, StatusInTransit, StatusException},
+	StatusException:      {StatusInTransit, StatusOutForDelivery, StatusReturned},
+	StatusDelivered:      {},
+	StatusReturned:       {},
+}
+
+// ErrInvalidTransition is returned when a status change is not allowed.
+var ErrInvalidTransition = errors.New("invalid status transition")
+
+// ShipmentEvent records one status change.
+type ShipmentEvent struct {
+	Status ShipmentStatus `json:"status"`
+	At     time.Time      `json:"at"`
+	Note   string         `json:"note,omitempty"`
+}
+
+// Shipment is a parcel that has been quoted and handed over for delivery.
+type Shipment struct {
+	TrackingNumber string          `json:"tracking_number"`
+	Request        QuoteRequest    `json:"request"`
+	Quote          Quote           `json:"quote"`
+	Status         ShipmentStatus  `json:"status"`
+	Events         []ShipmentEvent `json:"events"`
+}
+
+// NewShipment creates a shipment in the created state.
+func NewShipment(trackingNumber string, req QuoteRequest, q Quote, at time.Time) (*Shipment, error) {
+	if !ValidTrackingNumber(trackingNumber) {
+		return nil, fmt.Errorf("invalid tracking number: %s", trackingNumber)
+	}
+	return &Shipment{
+		TrackingNumber: trackingNumber,
+		Request:        req,
+		Quote:          q,
+		Status:         StatusCreated,
+		Events:         []ShipmentEvent{{Status: StatusCreated, At: at}},
+	}, nil
+}
+
+// Transition moves the shipment to status and records the event.
+// Events must be recorded in time order; a shipment with no events yet,
+// such as one built by hand, accepts any time.
+func (s *Shipment) Transition(status ShipmentStatus, at time.Time, note string) error {
+	allowed := false
+	for _, next := range transitions[s.Status] {
+		if next == status {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, s.Status, status)
+	}
+
+	if n := len(s.Events); n > 0 {
+		if last := s.Events[n-1]; at.Before(last.At) {
+			return fmt.Errorf("event at %s is before the previous event at %s", at.Format(time.RFC3339), last.At.Format(time.RFC3339))
+		}
+	}
+
+	s.Status = status
+	s.Events = append(s.Events, ShipmentEvent{Status: status, At: at, Note: note})
+	return nil
+}
+
+// Final reports whether the shipment can no longer change status.
+func (s *Shipment) Final() bool {
+	return len(transitions[s.Status]) == 0
+}
+
+// Tracking numbers follow the UPU S10 layout: a two-letter service code,
+// an eight-digit serial, a check digit and a two-letter country code,
+// e.g. SH000000015BT.
+var trackingWeights = [8]int{8, 6, 4, 2, 3, 5, 9, 7}
+
+// trackingCheckDigit computes the S10 check digit for an eight-digit serial.
+func trackingCheckDigit(serial string) int {
+	sum := 0
+	for i, r := range serial {
+		sum += int(r-'0') * trackingWeights[i]
+	}
+	check := 11 - sum%11
+	switch check {
+	case 10:
+		return 0
+	case 11:
+		return 5
+	}
+	return check
+}
+
+// FormatTrackingNumber builds a tracking number from its parts.
+func FormatTrackingNumber(service string, serial int, country string) (string, error) {
+	if len(service) != 2 || len(country) != 2 || !isLetters(service) || !isLetters(country) {
+		return "", errors.New("service and country codes must be two letters")
+	}
+	if serial < 0 || serial > 99999999 {
+		return "", fmt.Errorf("tracking serial out of range: %d", serial)
+	}
+
+	digits := fmt.Sprintf("%08d", serial)
+	return strings.ToUpper(service) + digits + strconv.Itoa(trackingCheckDigit(digits)) + strings.ToUpper(country), nil
+}
+
+// ValidTrackingNumber reports whether s is well formed and its check digit matches.
+func ValidTrackingNumber(s string) bool {
+	if len(s) != 13 || !isLetters(s[:2]) || !isLetters(s[11:]) {
+		return false
+	}
+	digits := s[2:10]
+	for _, r := range s[2:11] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return int(s[10]-'0') == trackingCheckDigit(digits)
+}
+
+// TrackingGenerator hands out sequential tracking numbers. It is safe for
+// concurrent use.
+type TrackingGenerator struct {
+	Service string
+	Country string
+
+	mu   sync.Mutex
+	next int
+}
+
+// NewTrackingGenerator starts a generator at the given serial.
+func NewTrackingGenerator(service, country string, start int) *TrackingGenerator {
+	return &TrackingGenerator{Service: service, Country: country, next: start}
+}
+
+// Next returns the next tracking number in the sequence.
+func (g *TrackingGenerator) Next() (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	number, err := FormatTrackingNumber(g.Service, g.next, g.Country)
+	if err != nil {
+		return "", err
+	}
+	g.next++
+	return number, nil
+}
+
+func isLetters(s string) bool {
+	for _, r := range s {
+		if (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') {
+			return false
+		}
+	}
+	return true
+}
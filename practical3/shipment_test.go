@@ -0,0 +1,101 @@
+// shipment_test.go
+package shipping
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTrackingNumbers(t *testing.T) {
+	// RR473124829GB is a published UPU S10 example
+	if !ValidTrackingNumber("RR473124829GB") {
+		t.Error("Expected the UPU example to be valid")
+	}
+
+	testCases := []struct {
+		name  string
+		input string
+		valid bool
+	}{
+		{"Wrong check digit", "RR473124828GB", false},
+		{"Too short", "RR47312482GB", false},
+		{"Digits in country", "RR47312482912", false},
+		{"Letters in serial", "RR4731X4829GB", false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ValidTrackingNumber(tc.input); got != tc.valid {
+				t.Errorf("Expected valid=%v for %s, got %v", tc.valid, tc.input, got)
+			}
+		})
+	}
+
+	gen := NewTrackingGenerator("SH", "BT", 47312482)
+	first, err := gen.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := gen.Next()
+	if first != "SH473124829BT" {
+		t.Errorf("Expected SH473124829BT, got %s", first)
+	}
+	if first == second || !ValidTrackingNumber(second) {
+		t.Errorf("Expected a new valid number, got %s", second)
+	}
+
+	if _, err := FormatTrackingNumber("S", 1, "BT"); err == nil {
+		t.Error("Expected an error for a one-letter service code")
+	}
+}
+
+func TestShipment_Transition(t *testing.T) {
+	start := time.Date(2025, time.December, 1, 9, 0, 0, 0, time.UTC)
+	s, err := NewShipment("SH473124829BT", QuoteRequest{Weight: 2, Zone: "Domestic"}, Quote{Total: 7}, start)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Happy path through to delivery
+	path := []ShipmentStatus{StatusPickedUp, StatusInTransit, StatusOutForDelivery, StatusDelivered}
+	for i, status := range path {
+		if err := s.Transition(status, start.Add(time.Duration(i+1)*time.Hour), ""); err != nil {
+			t.Fatalf("Unexpected error moving to %s: %v", status, err)
+		}
+	}
+	if !s.Final() || len(s.Events) != 5 {
+		t.Errorf("Expected a delivered shipment with 5 events, got %s with %d", s.Status, len(s.Events))
+	}
+
+	// Nothing may follow delivery
+	if err := s.Transition(StatusReturned, start.Add(10*time.Hour), ""); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("Expected ErrInvalidTransition, got %v", err)
+	}
+
+	// Skipping a stage is rejected
+	s, _ = NewShipment("SH473124829BT", QuoteRequest{}, Quote{}, start)
+	if err := s.Transition(StatusDelivered, start.Add(time.Hour), ""); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("Expected ErrInvalidTransition, got %v", err)
+	}
+
+	// Events cannot go back in time
+	if err := s.Transition(StatusPickedUp, start.Add(-time.Hour), ""); err == nil {
+		t.Error("Expected an error for an out-of-order event")
+	}
+
+	// Exceptions can end in a return
+	s.Transition(StatusException, start.Add(time.Hour), "address not found")
+	if err := s.Transition(StatusReturned, start.Add(2*time.Hour), ""); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	// A shipment with no recorded events starts from its status
+	bare := &Shipment{TrackingNumber: "SH473124829BT", Status: StatusCreated}
+	if err := bare.Transition(StatusPickedUp, start, ""); err != nil || len(bare.Events) != 1 {
+		t.Errorf("Expected the first event to be recorded, got %+v, %v", bare.Events, err)
+	}
+
+	if _, err := NewShipment("not-a-number", QuoteRequest{}, Quote{}, start); err == nil {
+		t.Error("Expected an error for a malformed tracking number")
+	}
+}
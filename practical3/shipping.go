@@ -1,16 +1,11 @@
 // shipping.go
 package shipping
 
-import (
-	"errors"
-	"fmt"
-)
-
 // CalculateShippingFee calculates the fee based on weight and zone.
 func CalculateShippingFee(weight float64, zone string) (float64, error) {
 	// This block directly implements Rule #1 and #4
 	if weight <= 0 || weight > 50 {
-		return 0, errors.New("invalid weight")
+		return 0, fieldError("weight", MsgInvalidWeight)
 	}
 
 	// This switch statement implements Rule #2, #3, and #5
@@ -23,6 +18,6 @@ func CalculateShippingFee(weight float64, zone string) (float64, error) {
 		return 30.0 + (weight * 5.0), nil
 	default:
 		// This handles any zone not explicitly listed above
-		return 0, fmt.Errorf("invalid zone: %s", zone)
+		return 0, fieldError("zone", MsgInvalidZone, zone)
 	}
-}
\ No newline at end of file
+}
@@ -2,6 +2,7 @@
 package shipping
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -117,4 +118,25 @@ func TestCalculateShippingFee_DecisionTable(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestCalculateShippingFee_ErrorCodes(t *testing.T) {
+	testCases := []struct {
+		weight  float64
+		zone    string
+		field   string
+		code    string
+		message string
+	}{
+		{0, "Domestic", "weight", MsgInvalidWeight, "invalid weight"},
+		{10, "Unknown", "zone", MsgInvalidZone, "invalid zone: Unknown"},
+	}
+
+	for _, tc := range testCases {
+		_, err := CalculateShippingFee(tc.weight, tc.zone)
+		var fe *FieldError
+		if !errors.As(err, &fe) || fe.Field != tc.field || fe.Code != tc.code || fe.Error() != tc.message {
+			t.Errorf("Expected %s on %s (%q), got %v", tc.code, tc.field, tc.message, err)
+		}
+	}
+}
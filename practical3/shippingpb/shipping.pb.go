@@ -0,0 +1,1221 @@
+// shipping.proto
+//
+// Typed RPC contract for shipping quotes. Regenerate the Go code with
+// protoc-gen-go and protoc-gen-go-grpc using paths=source_relative.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v5.29.3
+// source: shipping.proto
+
+package shippingpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Dimensions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Length        float64                `protobuf:"fixed64,1,opt,name=length,proto3" json:"length,omitempty"`
+	Width         float64                `protobuf:"fixed64,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height        float64                `protobuf:"fixed64,3,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Dimensions) Reset() {
+	*x = Dimensions{}
+	mi := &file_shipping_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Dimensions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dimensions) ProtoMessage() {}
+
+func (x *Dimensions) ProtoReflect() protoreflect.Message {
+	mi := &file_shipping_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dimensions.ProtoReflect.Descriptor instead.
+func (*Dimensions) Descriptor() ([]byte, []int) {
+	return file_shipping_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Dimensions) GetLength() float64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *Dimensions) GetWidth() float64 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Dimensions) GetHeight() float64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+type CustomsItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Description   string                 `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	HsCode        string                 `protobuf:"bytes,2,opt,name=hs_code,json=hsCode,proto3" json:"hs_code,omitempty"`
+	Value         float64                `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Origin        string                 `protobuf:"bytes,5,opt,name=origin,proto3" json:"origin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomsItem) Reset() {
+	*x = CustomsItem{}
+	mi := &file_shipping_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomsItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomsItem) ProtoMessage() {}
+
+func (x *CustomsItem) ProtoReflect() protoreflect.Message {
+	mi := &file_shipping_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomsItem.ProtoReflect.Descriptor instead.
+func (*CustomsItem) Descriptor() ([]byte, []int) {
+	return file_shipping_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CustomsItem) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CustomsItem) GetHsCode() string {
+	if x != nil {
+		return x.HsCode
+	}
+	return ""
+}
+
+func (x *CustomsItem) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *CustomsItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *CustomsItem) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
+}
+
+type QuoteRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Weight      float64                `protobuf:"fixed64,1,opt,name=weight,proto3" json:"weight,omitempty"`
+	Zone        string                 `protobuf:"bytes,2,opt,name=zone,proto3" json:"zone,omitempty"`
+	ShipDate    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=ship_date,json=shipDate,proto3" json:"ship_date,omitempty"`
+	Package     string                 `protobuf:"bytes,4,opt,name=package,proto3" json:"package,omitempty"`
+	Dimensions  *Dimensions            `protobuf:"bytes,5,opt,name=dimensions,proto3" json:"dimensions,omitempty"`
+	Currency    string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	CustomerId  string                 `protobuf:"bytes,7,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Destination string                 `protobuf:"bytes,8,opt,name=destination,proto3" json:"destination,omitempty"`
+	Items       []*CustomsItem         `protobuf:"bytes,9,rep,name=items,proto3" json:"items,omitempty"`
+	// Amount to collect on delivery. Unset means no cash on delivery.
+	CodAmount *float64 `protobuf:"fixed64,10,opt,name=cod_amount,json=codAmount,proto3,oneof" json:"cod_amount,omitempty"`
+	ReturnOf  string   `protobuf:"bytes,11,opt,name=return_of,json=returnOf,proto3" json:"return_of,omitempty"`
+	Insured   bool     `protobuf:"varint,12,opt,name=insured,proto3" json:"insured,omitempty"`
+	// Adds a fee to offset the shipment's estimated emissions.
+	CarbonOffset  bool `protobuf:"varint,13,opt,name=carbon_offset,json=carbonOffset,proto3" json:"carbon_offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoteRequest) Reset() {
+	*x = QuoteRequest{}
+	mi := &file_shipping_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteRequest) ProtoMessage() {}
+
+func (x *QuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipping_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteRequest.ProtoReflect.Descriptor instead.
+func (*QuoteRequest) Descriptor() ([]byte, []int) {
+	return file_shipping_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *QuoteRequest) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *QuoteRequest) GetZone() string {
+	if x != nil {
+		return x.Zone
+	}
+	return ""
+}
+
+func (x *QuoteRequest) GetShipDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ShipDate
+	}
+	return nil
+}
+
+func (x *QuoteRequest) GetPackage() string {
+	if x != nil {
+		return x.Package
+	}
+	return ""
+}
+
+func (x *QuoteRequest) GetDimensions() *Dimensions {
+	if x != nil {
+		return x.Dimensions
+	}
+	return nil
+}
+
+func (x *QuoteRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *QuoteRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *QuoteRequest) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *QuoteRequest) GetItems() []*CustomsItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *QuoteRequest) GetCodAmount() float64 {
+	if x != nil && x.CodAmount != nil {
+		return *x.CodAmount
+	}
+	return 0
+}
+
+func (x *QuoteRequest) GetReturnOf() string {
+	if x != nil {
+		return x.ReturnOf
+	}
+	return ""
+}
+
+func (x *QuoteRequest) GetInsured() bool {
+	if x != nil {
+		return x.Insured
+	}
+	return false
+}
+
+func (x *QuoteRequest) GetCarbonOffset() bool {
+	if x != nil {
+		return x.CarbonOffset
+	}
+	return false
+}
+
+type LineItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LineItem) Reset() {
+	*x = LineItem{}
+	mi := &file_shipping_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LineItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LineItem) ProtoMessage() {}
+
+func (x *LineItem) ProtoReflect() protoreflect.Message {
+	mi := &file_shipping_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LineItem.ProtoReflect.Descriptor instead.
+func (*LineItem) Descriptor() ([]byte, []int) {
+	return file_shipping_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LineItem) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *LineItem) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *LineItem) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type Presentment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Rate          float64                `protobuf:"fixed64,2,opt,name=rate,proto3" json:"rate,omitempty"`
+	RateAsOf      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=rate_as_of,json=rateAsOf,proto3" json:"rate_as_of,omitempty"`
+	Lines         []*LineItem            `protobuf:"bytes,4,rep,name=lines,proto3" json:"lines,omitempty"`
+	Total         float64                `protobuf:"fixed64,5,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Presentment) Reset() {
+	*x = Presentment{}
+	mi := &file_shipping_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Presentment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Presentment) ProtoMessage() {}
+
+func (x *Presentment) ProtoReflect() protoreflect.Message {
+	mi := &file_shipping_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Presentment.ProtoReflect.Descriptor instead.
+func (*Presentment) Descriptor() ([]byte, []int) {
+	return file_shipping_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Presentment) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Presentment) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *Presentment) GetRateAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RateAsOf
+	}
+	return nil
+}
+
+func (x *Presentment) GetLines() []*LineItem {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *Presentment) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type LandedCost struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Destination   string                 `protobuf:"bytes,1,opt,name=destination,proto3" json:"destination,omitempty"`
+	GoodsValue    float64                `protobuf:"fixed64,2,opt,name=goods_value,json=goodsValue,proto3" json:"goods_value,omitempty"`
+	DeMinimis     bool                   `protobuf:"varint,3,opt,name=de_minimis,json=deMinimis,proto3" json:"de_minimis,omitempty"`
+	Duty          float64                `protobuf:"fixed64,4,opt,name=duty,proto3" json:"duty,omitempty"`
+	Vat           float64                `protobuf:"fixed64,5,opt,name=vat,proto3" json:"vat,omitempty"`
+	Total         float64                `protobuf:"fixed64,6,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LandedCost) Reset() {
+	*x = LandedCost{}
+	mi := &file_shipping_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LandedCost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LandedCost) ProtoMessage() {}
+
+func (x *LandedCost) ProtoReflect() protoreflect.Message {
+	mi := &file_shipping_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LandedCost.ProtoReflect.Descriptor instead.
+func (*LandedCost) Descriptor() ([]byte, []int) {
+	return file_shipping_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LandedCost) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *LandedCost) GetGoodsValue() float64 {
+	if x != nil {
+		return x.GoodsValue
+	}
+	return 0
+}
+
+func (x *LandedCost) GetDeMinimis() bool {
+	if x != nil {
+		return x.DeMinimis
+	}
+	return false
+}
+
+func (x *LandedCost) GetDuty() float64 {
+	if x != nil {
+		return x.Duty
+	}
+	return 0
+}
+
+func (x *LandedCost) GetVat() float64 {
+	if x != nil {
+		return x.Vat
+	}
+	return 0
+}
+
+func (x *LandedCost) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type Quote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Lines         []*LineItem            `protobuf:"bytes,2,rep,name=lines,proto3" json:"lines,omitempty"`
+	Total         float64                `protobuf:"fixed64,3,opt,name=total,proto3" json:"total,omitempty"`
+	Presentment   *Presentment           `protobuf:"bytes,4,opt,name=presentment,proto3" json:"presentment,omitempty"`
+	Customs       *LandedCost            `protobuf:"bytes,5,opt,name=customs,proto3" json:"customs,omitempty"`
+	Emissions     *Emissions             `protobuf:"bytes,6,opt,name=emissions,proto3" json:"emissions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Quote) Reset() {
+	*x = Quote{}
+	mi := &file_shipping_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Quote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quote) ProtoMessage() {}
+
+func (x *Quote) ProtoReflect() protoreflect.Message {
+	mi := &file_shipping_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quote.ProtoReflect.Descriptor instead.
+func (*Quote) Descriptor() ([]byte, []int) {
+	return file_shipping_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Quote) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Quote) GetLines() []*LineItem {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *Quote) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *Quote) GetPresentment() *Presentment {
+	if x != nil {
+		return x.Presentment
+	}
+	return nil
+}
+
+func (x *Quote) GetCustoms() *LandedCost {
+	if x != nil {
+		return x.Customs
+	}
+	return nil
+}
+
+func (x *Quote) GetEmissions() *Emissions {
+	if x != nil {
+		return x.Emissions
+	}
+	return nil
+}
+
+type Emissions struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	ChargeableWeightKg float64                `protobuf:"fixed64,1,opt,name=chargeable_weight_kg,json=chargeableWeightKg,proto3" json:"chargeable_weight_kg,omitempty"`
+	DistanceKm         float64                `protobuf:"fixed64,2,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
+	// "road" or "air".
+	Mode          string  `protobuf:"bytes,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	Co2EKg        float64 `protobuf:"fixed64,4,opt,name=co2e_kg,json=co2eKg,proto3" json:"co2e_kg,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Emissions) Reset() {
+	*x = Emissions{}
+	mi := &file_shipping_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Emissions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Emissions) ProtoMessage() {}
+
+func (x *Emissions) ProtoReflect() protoreflect.Message {
+	mi := &file_shipping_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Emissions.ProtoReflect.Descriptor instead.
+func (*Emissions) Descriptor() ([]byte, []int) {
+	return file_shipping_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Emissions) GetChargeableWeightKg() float64 {
+	if x != nil {
+		return x.ChargeableWeightKg
+	}
+	return 0
+}
+
+func (x *Emissions) GetDistanceKm() float64 {
+	if x != nil {
+		return x.DistanceKm
+	}
+	return 0
+}
+
+func (x *Emissions) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *Emissions) GetCo2EKg() float64 {
+	if x != nil {
+		return x.Co2EKg
+	}
+	return 0
+}
+
+type QuoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Quote         *Quote                 `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoteResponse) Reset() {
+	*x = QuoteResponse{}
+	mi := &file_shipping_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteResponse) ProtoMessage() {}
+
+func (x *QuoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shipping_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteResponse.ProtoReflect.Descriptor instead.
+func (*QuoteResponse) Descriptor() ([]byte, []int) {
+	return file_shipping_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *QuoteResponse) GetQuote() *Quote {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+type RateShopRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The parcel to price. Its zone is ignored.
+	Request *QuoteRequest `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	// Zones to compare. Empty means every zone on the rate card.
+	Zones         []string `protobuf:"bytes,2,rep,name=zones,proto3" json:"zones,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateShopRequest) Reset() {
+	*x = RateShopRequest{}
+	mi := &file_shipping_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateShopRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateShopRequest) ProtoMessage() {}
+
+func (x *RateShopRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipping_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateShopRequest.ProtoReflect.Descriptor instead.
+func (*RateShopRequest) Descriptor() ([]byte, []int) {
+	return file_shipping_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RateShopRequest) GetRequest() *QuoteRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *RateShopRequest) GetZones() []string {
+	if x != nil {
+		return x.Zones
+	}
+	return nil
+}
+
+type RateOption struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Zone  string                 `protobuf:"bytes,1,opt,name=zone,proto3" json:"zone,omitempty"`
+	// Set when the zone can carry the parcel.
+	Quote *Quote `protobuf:"bytes,2,opt,name=quote,proto3" json:"quote,omitempty"`
+	// Set when it cannot, e.g. a package type not allowed in the zone.
+	UnavailableReason string `protobuf:"bytes,3,opt,name=unavailable_reason,json=unavailableReason,proto3" json:"unavailable_reason,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RateOption) Reset() {
+	*x = RateOption{}
+	mi := &file_shipping_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateOption) ProtoMessage() {}
+
+func (x *RateOption) ProtoReflect() protoreflect.Message {
+	mi := &file_shipping_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateOption.ProtoReflect.Descriptor instead.
+func (*RateOption) Descriptor() ([]byte, []int) {
+	return file_shipping_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RateOption) GetZone() string {
+	if x != nil {
+		return x.Zone
+	}
+	return ""
+}
+
+func (x *RateOption) GetQuote() *Quote {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+func (x *RateOption) GetUnavailableReason() string {
+	if x != nil {
+		return x.UnavailableReason
+	}
+	return ""
+}
+
+type RateShopResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Available options cheapest first, followed by unavailable ones.
+	Options       []*RateOption `protobuf:"bytes,1,rep,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateShopResponse) Reset() {
+	*x = RateShopResponse{}
+	mi := &file_shipping_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateShopResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateShopResponse) ProtoMessage() {}
+
+func (x *RateShopResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shipping_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateShopResponse.ProtoReflect.Descriptor instead.
+func (*RateShopResponse) Descriptor() ([]byte, []int) {
+	return file_shipping_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RateShopResponse) GetOptions() []*RateOption {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type ListZonesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListZonesRequest) Reset() {
+	*x = ListZonesRequest{}
+	mi := &file_shipping_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListZonesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListZonesRequest) ProtoMessage() {}
+
+func (x *ListZonesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipping_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListZonesRequest.ProtoReflect.Descriptor instead.
+func (*ListZonesRequest) Descriptor() ([]byte, []int) {
+	return file_shipping_proto_rawDescGZIP(), []int{12}
+}
+
+type Zone struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Base          float64                `protobuf:"fixed64,2,opt,name=base,proto3" json:"base,omitempty"`
+	PerKg         float64                `protobuf:"fixed64,3,opt,name=per_kg,json=perKg,proto3" json:"per_kg,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Zone) Reset() {
+	*x = Zone{}
+	mi := &file_shipping_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Zone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Zone) ProtoMessage() {}
+
+func (x *Zone) ProtoReflect() protoreflect.Message {
+	mi := &file_shipping_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Zone.ProtoReflect.Descriptor instead.
+func (*Zone) Descriptor() ([]byte, []int) {
+	return file_shipping_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Zone) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Zone) GetBase() float64 {
+	if x != nil {
+		return x.Base
+	}
+	return 0
+}
+
+func (x *Zone) GetPerKg() float64 {
+	if x != nil {
+		return x.PerKg
+	}
+	return 0
+}
+
+type ListZonesResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RateCardVersion string                 `protobuf:"bytes,1,opt,name=rate_card_version,json=rateCardVersion,proto3" json:"rate_card_version,omitempty"`
+	MaxWeight       float64                `protobuf:"fixed64,2,opt,name=max_weight,json=maxWeight,proto3" json:"max_weight,omitempty"`
+	Zones           []*Zone                `protobuf:"bytes,3,rep,name=zones,proto3" json:"zones,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListZonesResponse) Reset() {
+	*x = ListZonesResponse{}
+	mi := &file_shipping_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListZonesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListZonesResponse) ProtoMessage() {}
+
+func (x *ListZonesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shipping_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListZonesResponse.ProtoReflect.Descriptor instead.
+func (*ListZonesResponse) Descriptor() ([]byte, []int) {
+	return file_shipping_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListZonesResponse) GetRateCardVersion() string {
+	if x != nil {
+		return x.RateCardVersion
+	}
+	return ""
+}
+
+func (x *ListZonesResponse) GetMaxWeight() float64 {
+	if x != nil {
+		return x.MaxWeight
+	}
+	return 0
+}
+
+func (x *ListZonesResponse) GetZones() []*Zone {
+	if x != nil {
+		return x.Zones
+	}
+	return nil
+}
+
+var File_shipping_proto protoreflect.FileDescriptor
+
+const file_shipping_proto_rawDesc = "" +
+	"\n" +
+	"\x0eshipping.proto\x12\vshipping.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"R\n" +
+	"\n" +
+	"Dimensions\x12\x16\n" +
+	"\x06length\x18\x01 \x01(\x01R\x06length\x12\x14\n" +
+	"\x05width\x18\x02 \x01(\x01R\x05width\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x01R\x06height\"\x92\x01\n" +
+	"\vCustomsItem\x12 \n" +
+	"\vdescription\x18\x01 \x01(\tR\vdescription\x12\x17\n" +
+	"\ahs_code\x18\x02 \x01(\tR\x06hsCode\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\x01R\x05value\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x16\n" +
+	"\x06origin\x18\x05 \x01(\tR\x06origin\"\xe4\x03\n" +
+	"\fQuoteRequest\x12\x16\n" +
+	"\x06weight\x18\x01 \x01(\x01R\x06weight\x12\x12\n" +
+	"\x04zone\x18\x02 \x01(\tR\x04zone\x127\n" +
+	"\tship_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bshipDate\x12\x18\n" +
+	"\apackage\x18\x04 \x01(\tR\apackage\x127\n" +
+	"\n" +
+	"dimensions\x18\x05 \x01(\v2\x17.shipping.v1.DimensionsR\n" +
+	"dimensions\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12\x1f\n" +
+	"\vcustomer_id\x18\a \x01(\tR\n" +
+	"customerId\x12 \n" +
+	"\vdestination\x18\b \x01(\tR\vdestination\x12.\n" +
+	"\x05items\x18\t \x03(\v2\x18.shipping.v1.CustomsItemR\x05items\x12\"\n" +
+	"\n" +
+	"cod_amount\x18\n" +
+	" \x01(\x01H\x00R\tcodAmount\x88\x01\x01\x12\x1b\n" +
+	"\treturn_of\x18\v \x01(\tR\breturnOf\x12\x18\n" +
+	"\ainsured\x18\f \x01(\bR\ainsured\x12#\n" +
+	"\rcarbon_offset\x18\r \x01(\bR\fcarbonOffsetB\r\n" +
+	"\v_cod_amount\"X\n" +
+	"\bLineItem\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\"\xba\x01\n" +
+	"\vPresentment\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x12\n" +
+	"\x04rate\x18\x02 \x01(\x01R\x04rate\x128\n" +
+	"\n" +
+	"rate_as_of\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\brateAsOf\x12+\n" +
+	"\x05lines\x18\x04 \x03(\v2\x15.shipping.v1.LineItemR\x05lines\x12\x14\n" +
+	"\x05total\x18\x05 \x01(\x01R\x05total\"\xaa\x01\n" +
+	"\n" +
+	"LandedCost\x12 \n" +
+	"\vdestination\x18\x01 \x01(\tR\vdestination\x12\x1f\n" +
+	"\vgoods_value\x18\x02 \x01(\x01R\n" +
+	"goodsValue\x12\x1d\n" +
+	"\n" +
+	"de_minimis\x18\x03 \x01(\bR\tdeMinimis\x12\x12\n" +
+	"\x04duty\x18\x04 \x01(\x01R\x04duty\x12\x10\n" +
+	"\x03vat\x18\x05 \x01(\x01R\x03vat\x12\x14\n" +
+	"\x05total\x18\x06 \x01(\x01R\x05total\"\x8b\x02\n" +
+	"\x05Quote\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12+\n" +
+	"\x05lines\x18\x02 \x03(\v2\x15.shipping.v1.LineItemR\x05lines\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x01R\x05total\x12:\n" +
+	"\vpresentment\x18\x04 \x01(\v2\x18.shipping.v1.PresentmentR\vpresentment\x121\n" +
+	"\acustoms\x18\x05 \x01(\v2\x17.shipping.v1.LandedCostR\acustoms\x124\n" +
+	"\temissions\x18\x06 \x01(\v2\x16.shipping.v1.EmissionsR\temissions\"\x8b\x01\n" +
+	"\tEmissions\x120\n" +
+	"\x14chargeable_weight_kg\x18\x01 \x01(\x01R\x12chargeableWeightKg\x12\x1f\n" +
+	"\vdistance_km\x18\x02 \x01(\x01R\n" +
+	"distanceKm\x12\x12\n" +
+	"\x04mode\x18\x03 \x01(\tR\x04mode\x12\x17\n" +
+	"\aco2e_kg\x18\x04 \x01(\x01R\x06co2eKg\"9\n" +
+	"\rQuoteResponse\x12(\n" +
+	"\x05quote\x18\x01 \x01(\v2\x12.shipping.v1.QuoteR\x05quote\"\\\n" +
+	"\x0fRateShopRequest\x123\n" +
+	"\arequest\x18\x01 \x01(\v2\x19.shipping.v1.QuoteRequestR\arequest\x12\x14\n" +
+	"\x05zones\x18\x02 \x03(\tR\x05zones\"y\n" +
+	"\n" +
+	"RateOption\x12\x12\n" +
+	"\x04zone\x18\x01 \x01(\tR\x04zone\x12(\n" +
+	"\x05quote\x18\x02 \x01(\v2\x12.shipping.v1.QuoteR\x05quote\x12-\n" +
+	"\x12unavailable_reason\x18\x03 \x01(\tR\x11unavailableReason\"E\n" +
+	"\x10RateShopResponse\x121\n" +
+	"\aoptions\x18\x01 \x03(\v2\x17.shipping.v1.RateOptionR\aoptions\"\x12\n" +
+	"\x10ListZonesRequest\"E\n" +
+	"\x04Zone\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04base\x18\x02 \x01(\x01R\x04base\x12\x15\n" +
+	"\x06per_kg\x18\x03 \x01(\x01R\x05perKg\"\x87\x01\n" +
+	"\x11ListZonesResponse\x12*\n" +
+	"\x11rate_card_version\x18\x01 \x01(\tR\x0frateCardVersion\x12\x1d\n" +
+	"\n" +
+	"max_weight\x18\x02 \x01(\x01R\tmaxWeight\x12'\n" +
+	"\x05zones\x18\x03 \x03(\v2\x11.shipping.v1.ZoneR\x05zones2\xe5\x01\n" +
+	"\x0eQuotingService\x12>\n" +
+	"\x05Quote\x12\x19.shipping.v1.QuoteRequest\x1a\x1a.shipping.v1.QuoteResponse\x12G\n" +
+	"\bRateShop\x12\x1c.shipping.v1.RateShopRequest\x1a\x1d.shipping.v1.RateShopResponse\x12J\n" +
+	"\tListZones\x12\x1d.shipping.v1.ListZonesRequest\x1a\x1e.shipping.v1.ListZonesResponseB\x15Z\x13shipping/shippingpbb\x06proto3"
+
+var (
+	file_shipping_proto_rawDescOnce sync.Once
+	file_shipping_proto_rawDescData []byte
+)
+
+func file_shipping_proto_rawDescGZIP() []byte {
+	file_shipping_proto_rawDescOnce.Do(func() {
+		file_shipping_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_shipping_proto_rawDesc), len(file_shipping_proto_rawDesc)))
+	})
+	return file_shipping_proto_rawDescData
+}
+
+var file_shipping_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_shipping_proto_goTypes = []any{
+	(*Dimensions)(nil),            // 0: shipping.v1.Dimensions
+	(*CustomsItem)(nil),           // 1: shipping.v1.CustomsItem
+	(*QuoteRequest)(nil),          // 2: shipping.v1.QuoteRequest
+	(*LineItem)(nil),              // 3: shipping.v1.LineItem
+	(*Presentment)(nil),           // 4: shipping.v1.Presentment
+	(*LandedCost)(nil),            // 5: shipping.v1.LandedCost
+	(*Quote)(nil),                 // 6: shipping.v1.Quote
+	(*Emissions)(nil),             // 7: shipping.v1.Emissions
+	(*QuoteResponse)(nil),         // 8: shipping.v1.QuoteResponse
+	(*RateShopRequest)(nil),       // 9: shipping.v1.RateShopRequest
+	(*RateOption)(nil),            // 10: shipping.v1.RateOption
+	(*RateShopResponse)(nil),      // 11: shipping.v1.RateShopResponse
+	(*ListZonesRequest)(nil),      // 12: shipping.v1.ListZonesRequest
+	(*Zone)(nil),                  // 13: shipping.v1.Zone
+	(*ListZonesResponse)(nil),     // 14: shipping.v1.ListZonesResponse
+	(*timestamppb.Timestamp)(nil), // 15: google.protobuf.Timestamp
+}
+var file_shipping_proto_depIdxs = []int32{
+	15, // 0: shipping.v1.QuoteRequest.ship_date:type_name -> google.protobuf.Timestamp
+	0,  // 1: shipping.v1.QuoteRequest.dimensions:type_name -> shipping.v1.Dimensions
+	1,  // 2: shipping.v1.QuoteRequest.items:type_name -> shipping.v1.CustomsItem
+	15, // 3: shipping.v1.Presentment.rate_as_of:type_name -> google.protobuf.Timestamp
+	3,  // 4: shipping.v1.Presentment.lines:type_name -> shipping.v1.LineItem
+	3,  // 5: shipping.v1.Quote.lines:type_name -> shipping.v1.LineItem
+	4,  // 6: shipping.v1.Quote.presentment:type_name -> shipping.v1.Presentment
+	5,  // 7: shipping.v1.Quote.customs:type_name -> shipping.v1.LandedCost
+	7,  // 8: shipping.v1.Quote.emissions:type_name -> shipping.v1.Emissions
+	6,  // 9: shipping.v1.QuoteResponse.quote:type_name -> shipping.v1.Quote
+	2,  // 10: shipping.v1.RateShopRequest.request:type_name -> shipping.v1.QuoteRequest
+	6,  // 11: shipping.v1.RateOption.quote:type_name -> shipping.v1.Quote
+	10, // 12: shipping.v1.RateShopResponse.options:type_name -> shipping.v1.RateOption
+	13, // 13: shipping.v1.ListZonesResponse.zones:type_name -> shipping.v1.Zone
+	2,  // 14: shipping.v1.QuotingService.Quote:input_type -> shipping.v1.QuoteRequest
+	9,  // 15: shipping.v1.QuotingService.RateShop:input_type -> shipping.v1.RateShopRequest
+	12, // 16: shipping.v1.QuotingService.ListZones:input_type -> shipping.v1.ListZonesRequest
+	8,  // 17: shipping.v1.QuotingService.Quote:output_type -> shipping.v1.QuoteResponse
+	11, // 18: shipping.v1.QuotingService.RateShop:output_type -> shipping.v1.RateShopResponse
+	14, // 19: shipping.v1.QuotingService.ListZones:output_type -> shipping.v1.ListZonesResponse
+	17, // [17:20] is the sub-list for method output_type
+	14, // [14:17] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_shipping_proto_init() }
+func file_shipping_proto_init() {
+	if File_shipping_proto != nil {
+		return
+	}
+	file_shipping_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shipping_proto_rawDesc), len(file_shipping_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_shipping_proto_goTypes,
+		DependencyIndexes: file_shipping_proto_depIdxs,
+		MessageInfos:      file_shipping_proto_msgTypes,
+	}.Build()
+	File_shipping_proto = out.File
+	file_shipping_proto_goTypes = nil
+	file_shipping_proto_depIdxs = nil
+}
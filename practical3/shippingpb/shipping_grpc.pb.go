@@ -0,0 +1,212 @@
+// shipping.proto
+//
+// Typed RPC contract for shipping quotes. Regenerate the Go code with
+// protoc-gen-go and protoc-gen-go-grpc using paths=source_relative.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: shipping.proto
+
+package shippingpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	QuotingService_Quote_FullMethodName     = "/shipping.v1.QuotingService/Quote"
+	QuotingService_RateShop_FullMethodName  = "/shipping.v1.QuotingService/RateShop"
+	QuotingService_ListZones_FullMethodName = "/shipping.v1.QuotingService/ListZones"
+)
+
+// QuotingServiceClient is the client API for QuotingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// QuotingService prices parcels with the shipping calculators.
+type QuotingServiceClient interface {
+	// Quote prices a single request.
+	Quote(ctx context.Context, in *QuoteRequest, opts ...grpc.CallOption) (*QuoteResponse, error)
+	// RateShop prices the same parcel in several zones so the caller can compare.
+	RateShop(ctx context.Context, in *RateShopRequest, opts ...grpc.CallOption) (*RateShopResponse, error)
+	// ListZones returns the zones and rates of the active rate card.
+	ListZones(ctx context.Context, in *ListZonesRequest, opts ...grpc.CallOption) (*ListZonesResponse, error)
+}
+
+type quotingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewQuotingServiceClient(cc grpc.ClientConnInterface) QuotingServiceClient {
+	return &quotingServiceClient{cc}
+}
+
+func (c *quotingServiceClient) Quote(ctx context.Context, in *QuoteRequest, opts ...grpc.CallOption) (*QuoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QuoteResponse)
+	err := c.cc.Invoke(ctx, QuotingService_Quote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quotingServiceClient) RateShop(ctx context.Context, in *RateShopRequest, opts ...grpc.CallOption) (*RateShopResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RateShopResponse)
+	err := c.cc.Invoke(ctx, QuotingService_RateShop_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quotingServiceClient) ListZones(ctx context.Context, in *ListZonesRequest, opts ...grpc.CallOption) (*ListZonesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListZonesResponse)
+	err := c.cc.Invoke(ctx, QuotingService_ListZones_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// QuotingServiceServer is the server API for QuotingService service.
+// All implementations must embed UnimplementedQuotingServiceServer
+// for forward compatibility.
+//
+// QuotingService prices parcels with the shipping calculators.
+type QuotingServiceServer interface {
+	// Quote prices a single request.
+	Quote(context.Context, *QuoteRequest) (*QuoteResponse, error)
+	// RateShop prices the same parcel in several zones so the caller can compare.
+	RateShop(context.Context, *RateShopRequest) (*RateShopResponse, error)
+	// ListZones returns the zones and rates of the active rate card.
+	ListZones(context.Context, *ListZonesRequest) (*ListZonesResponse, error)
+	mustEmbedUnimplementedQuotingServiceServer()
+}
+
+// UnimplementedQuotingServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedQuotingServiceServer struct{}
+
+func (UnimplementedQuotingServiceServer) Quote(context.Context, *QuoteRequest) (*QuoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Quote not implemented")
+}
+func (UnimplementedQuotingServiceServer) RateShop(context.Context, *RateShopRequest) (*RateShopResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RateShop not implemented")
+}
+func (UnimplementedQuotingServiceServer) ListZones(context.Context, *ListZonesRequest) (*ListZonesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListZones not implemented")
+}
+func (UnimplementedQuotingServiceServer) mustEmbedUnimplementedQuotingServiceServer() {}
+func (UnimplementedQuotingServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeQuotingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to QuotingServiceServer will
+// result in compilation errors.
+type UnsafeQuotingServiceServer interface {
+	mustEmbedUnimplementedQuotingServiceServer()
+}
+
+func RegisterQuotingServiceServer(s grpc.ServiceRegistrar, srv QuotingServiceServer) {
+	// If the following call pancis, it indicates UnimplementedQuotingServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&QuotingService_ServiceDesc, srv)
+}
+
+func _QuotingService_Quote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuotingServiceServer).Quote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuotingService_Quote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuotingServiceServer).Quote(ctx, req.(*QuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuotingService_RateShop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RateShopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuotingServiceServer).RateShop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuotingService_RateShop_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuotingServiceServer).RateShop(ctx, req.(*RateShopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuotingService_ListZones_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListZonesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuotingServiceServer).ListZones(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuotingService_ListZones_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuotingServiceServer).ListZones(ctx, req.(*ListZonesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// QuotingService_ServiceDesc is the grpc.ServiceDesc for QuotingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var QuotingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "shipping.v1.QuotingService",
+	HandlerType: (*QuotingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Quote",
+			Handler:    _QuotingService_Quote_Handler,
+		},
+		{
+			MethodName: "RateShop",
+			Handler:    _QuotingService_RateShop_Handler,
+		},
+		{
+			MethodName: "ListZones",
+			Handler:    _QuotingService_ListZones_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "shipping.proto",
+}
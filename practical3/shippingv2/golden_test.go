@@ -0,0 +1,36 @@
+// golden_test.go
+package shipping
+
+import (
+	"bytes"
+	"flag"
+	"path/filepath"
+	"testing"
+
+	pricing "shipping"
+)
+
+// Run `go test -run Golden -update` to accept intentional pricing changes.
+var update = flag.Bool("update", false, "rewrite golden pricing files")
+
+func TestGolden_V2(t *testing.T) {
+	path := filepath.Join("testdata", "shipping_v2.golden.json")
+	grid := pricing.SnapshotGrid(pricing.DefaultSnapshotWeights, pricing.DefaultSnapshotZones)
+	current := pricing.TakeSnapshot("shippingv2.CalculateShippingFee", CalculateShippingFee, grid)
+
+	if *update {
+		if err := current.WriteFile(path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	golden, err := pricing.ReadSnapshot(path)
+	if err != nil {
+		t.Fatalf("Could not read golden file (run with -update to create it): %v", err)
+	}
+	if diff := pricing.DiffSnapshots(golden, current); !diff.Empty() {
+		var report bytes.Buffer
+		diff.WriteReport(&report)
+		t.Errorf("Pricing differs from %s:\n%s", path, report.String())
+	}
+}
@@ -1,15 +1,12 @@
 // shipping_v2.go
 package shipping
 
-import (
-	"errors"
-	"fmt"
-)
+import pricing "shipping"
 
 // CalculateShippingFee calculates the fee based on new tiered logic.
 func CalculateShippingFee(weight float64, zone string, insured bool) (float64, error) {
 	if weight <= 0 || weight > 50 {
-		return 0, errors.New("invalid weight")
+		return 0, pricing.NewFieldError("weight", pricing.MsgInvalidWeight)
 	}
 
 	var baseFee float64
@@ -21,7 +18,7 @@ func CalculateShippingFee(weight float64, zone string, insured bool) (float64, e
 	case "Express":
 		baseFee = 30.0
 	default:
-		return 0, fmt.Errorf("invalid zone: %s", zone)
+		return 0, pricing.NewFieldError("zone", pricing.MsgInvalidZone, zone)
 	}
 
 	var heavySurcharge float64
@@ -39,4 +36,4 @@ func CalculateShippingFee(weight float64, zone string, insured bool) (float64, e
 	finalTotal := subTotal + insuranceCost
 
 	return finalTotal, nil
-}
\ No newline at end of file
+}
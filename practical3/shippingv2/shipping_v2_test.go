@@ -1,8 +1,11 @@
 package shipping
 
 import (
+	"errors"
 	"testing"
 	"math"
+
+	pricing "shipping"
 )
 
 func TestCalculateShippingFee_V2(t *testing.T) {
@@ -96,3 +99,13 @@ func TestCalculateShippingFee_V2(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculateShippingFee_V2ErrorCodes(t *testing.T) {
+	var fe *pricing.FieldError
+	if _, err := CalculateShippingFee(51, "Domestic", false); !errors.As(err, &fe) || fe.Code != pricing.MsgInvalidWeight {
+		t.Errorf("Expected %s, got %v", pricing.MsgInvalidWeight, err)
+	}
+	if _, err := CalculateShippingFee(10, "Unknown", false); !errors.As(err, &fe) || fe.Code != pricing.MsgInvalidZone || fe.Field != "zone" {
+		t.Errorf("Expected %s on zone, got %v", pricing.MsgInvalidZone, err)
+	}
+}
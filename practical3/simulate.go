@@ -0,0 +1,308 @@
+// simulate.go
+package shipping
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// HistoricalShipment is one past shipment replayed by the Simulator.
+type HistoricalShipment struct {
+	CustomerID string  `json:"customer_id"`
+	Segment    string  `json:"segment"`
+	Weight     float64 `json:"weight"`
+	Zone       string  `json:"zone"`
+	Insured    bool    `json:"insured"`
+}
+
+// LoadHistory reads shipments from CSV with the header
+// customer_id,segment,weight,zone,insured.
+func LoadHistory(r io.Reader) ([]HistoricalShipment, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 5
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("reading shipment history: %w", err)
+	}
+	if len(records) == 0 || records[0][0] != "customer_id" {
+		return nil, errors.New("shipment history must start with a customer_id,segment,weight,zone,insured header")
+	}
+
+	history := make([]HistoricalShipment, 0, len(records)-1)
+	for i, rec := range records[1:] {
+		weight, err := strconv.ParseFloat(rec[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("shipment history line %d: invalid weight %q", i+2, rec[2])
+		}
+		insured, err := strconv.ParseBool(rec[4])
+		if err != nil {
+			return nil, fmt.Errorf("shipment history line %d: invalid insured flag %q", i+2, rec[4])
+		}
+		history = append(history, HistoricalShipment{
+			CustomerID: rec[0],
+			Segment:    rec[1],
+			Weight:     weight,
+			Zone:       rec[3],
+			Insured:    insured,
+		})
+	}
+	return history, nil
+}
+
+// WeightBand groups shipments up to and including Max kilograms.
+type WeightBand struct {
+	Label string
+	Max   float64
+}
+
+// DefaultWeightBands splits parcels around the weights our cards care about.
+var DefaultWeightBands = []WeightBand{
+	{"0-1kg", 1},
+	{"1-5kg", 5},
+	{"5-10kg", 10},
+	{"10-20kg", 20},
+	{"20-50kg", 50},
+}
+
+// RevenueDelta compares revenue under two rate cards for one group of shipments.
+type RevenueDelta struct {
+	Group        string  `json:"group"`
+	Shipments    int     `json:"shipments"`
+	Current      float64 `json:"current"`
+	Proposed     float64 `json:"proposed"`
+	Delta        float64 `json:"delta"`
+	DeltaPercent float64 `json:"delta_percent"`
+}
+
+// ChangeBucket counts customers whose total changed by a percentage in
+// the range (Min, Max].
+type ChangeBucket struct {
+	Label     string  `json:"label"`
+	Min       float64 `json:"min"`
+	Max       float64 `json:"max"`
+	Customers int     `json:"customers"`
+}
+
+// ChangeDistribution summarises per-customer percentage price changes.
+type ChangeDistribution struct {
+	Customers int            `json:"customers"`
+	Min       float64        `json:"min"`
+	P10       float64        `json:"p10"`
+	Median    float64        `json:"median"`
+	P90       float64        `json:"p90"`
+	Max       float64        `json:"max"`
+	Buckets   []ChangeBucket `json:"buckets"`
+}
+
+// SimulationReport is the outcome of replaying history against two cards.
+type SimulationReport struct {
+	CurrentVersion  string             `json:"current_version"`
+	ProposedVersion string             `json:"proposed_version"`
+	Total           RevenueDelta       `json:"total"`
+	ByZone          []RevenueDelta     `json:"by_zone"`
+	ByWeightBand    []RevenueDelta     `json:"by_weight_band"`
+	BySegment       []RevenueDelta     `json:"by_segment"`
+	CustomerChanges ChangeDistribution `json:"customer_changes"`
+	// Rejected counts shipments one of the cards refuses to price.
+	Rejected int `json:"rejected"`
+}
+
+// Simulator replays historical shipments against the current and a
+// proposed rate card to show the revenue impact of a change.
+type Simulator struct {
+	Current     RateCard
+	Proposed    RateCard
+	WeightBands []WeightBand
+}
+
+// changeBuckets are the ranges used for the per-customer distribution.
+var changeBuckets = []ChangeBucket{
+	{Label: "< -10%", Min: math.Inf(-1), Max: -10},
+	{Label: "-10% to -5%", Min: -10, Max: -5},
+	{Label: "-5% to 0%", Min: -5, Max: -0.005},
+	{Label: "unchanged", Min: -0.005, Max: 0.005},
+	{Label: "0% to 5%", Min: 0.005, Max: 5},
+	{Label: "5% to 10%", Min: 5, Max: 10},
+	{Label: "> 10%", Min: 10, Max: math.Inf(1)},
+}
+
+// Run prices every shipment under both cards and aggregates the results.
+func (s Simulator) Run(history []HistoricalShipment) (SimulationReport, error) {
+	if err := s.Current.Validate(); err != nil {
+		return SimulationReport{}, fmt.Errorf("current rate card: %w", err)
+	}
+	if err := s.Proposed.Validate(); err != nil {
+		return SimulationReport{}, fmt.Errorf("proposed rate card: %w", err)
+	}
+	bands := s.WeightBands
+	if len(bands) == 0 {
+		bands = DefaultWeightBands
+	}
+
+	report := SimulationReport{CurrentVersion: s.Current.Version, ProposedVersion: s.Proposed.Version}
+	total := &RevenueDelta{Group: "total"}
+	byZone := make(map[string]*RevenueDelta)
+	byBand := make(map[string]*RevenueDelta)
+	bySegment := make(map[string]*RevenueDelta)
+	byCustomer := make(map[string]*RevenueDelta)
+
+	for _, h := range history {
+		current, err := s.Current.Price(h.Weight, h.Zone, h.Insured)
+		if err != nil {
+			report.Rejected++
+			continue
+		}
+		proposed, err := s.Proposed.Price(h.Weight, h.Zone, h.Insured)
+		if err != nil {
+			report.Rejected++
+			continue
+		}
+
+		for _, d := range []*RevenueDelta{
+			total,
+			group(byZone, h.Zone),
+			group(byBand, bandFor(bands, h.Weight)),
+			group(bySegment, h.Segment),
+			group(byCustomer, h.CustomerID),
+		} {
+			d.Shipments++
+			d.Current += roundCents(current)
+			d.Proposed += roundCents(proposed)
+		}
+	}
+
+	report.Total = total.finish()
+	report.ByZone = sortedDeltas(byZone)
+	report.ByWeightBand = bandDeltas(bands, byBand)
+	report.BySegment = sortedDeltas(bySegment)
+	report.CustomerChanges = distribution(byCustomer)
+	return report, nil
+}
+
+func group(groups map[string]*RevenueDelta, key string) *RevenueDelta {
+	d, ok := groups[key]
+	if !ok {
+		d = &RevenueDelta{Group: key}
+		groups[key] = d
+	}
+	return d
+}
+
+func bandFor(bands []WeightBand, weight float64) string {
+	for _, b := range bands {
+		if weight <= b.Max {
+			return b.Label
+		}
+	}
+	return bands[len(bands)-1].Label
+}
+
+// finish rounds the totals and fills in the delta fields.
+func (d *RevenueDelta) finish() RevenueDelta {
+	d.Current = roundCents(d.Current)
+	d.Proposed = roundCents(d.Proposed)
+	d.Delta = roundCents(d.Proposed - d.Current)
+	if d.Current != 0 {
+		d.DeltaPercent = roundCents(d.Delta / d.Current * 100)
+	}
+	return *d
+}
+
+func sortedDeltas(groups map[string]*RevenueDelta) []RevenueDelta {
+	deltas := make([]RevenueDelta, 0, len(groups))
+	for _, d := range groups {
+		deltas = append(deltas, d.finish())
+	}
+	sort.Slice(deltas, func(i, j int) bool { return deltas[i].Group < deltas[j].Group })
+	return deltas
+}
+
+// bandDeltas lists the weight bands in the order they are defined, lightest
+// first, skipping bands with no shipments.
+func bandDeltas(bands []WeightBand, groups map[string]*RevenueDelta) []RevenueDelta {
+	deltas := make([]RevenueDelta, 0, len(groups))
+	for _, b := range bands {
+		if d, ok := groups[b.Label]; ok {
+			deltas = append(deltas, d.finish())
+			// Bands sharing a label are reported once
+			delete(groups, b.Label)
+		}
+	}
+	return deltas
+}
+
+// distribution summarises how each customer's total changed, in percent.
+func distribution(byCustomer map[string]*RevenueDelta) ChangeDistribution {
+	dist := ChangeDistribution{Buckets: append([]ChangeBucket(nil), changeBuckets...)}
+
+	var changes []float64
+	for _, d := range byCustomer {
+		changes = append(changes, d.finish().DeltaPercent)
+	}
+	if len(changes) == 0 {
+		return dist
+	}
+	sort.Float64s(changes)
+
+	dist.Customers = len(changes)
+	dist.Min = changes[0]
+	dist.Max = changes[len(changes)-1]
+	dist.P10 = percentile(changes, 0.10)
+	dist.Median = percentile(changes, 0.50)
+	dist.P90 = percentile(changes, 0.90)
+
+	for _, c := range changes {
+		for i := range dist.Buckets {
+			if c > dist.Buckets[i].Min && c <= dist.Buckets[i].Max {
+				dist.Buckets[i].Customers++
+				break
+			}
+		}
+	}
+	return dist
+}
+
+// percentile uses the nearest-rank method on sorted values.
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+// WriteText writes the report as aligned plain-text tables.
+func (r SimulationReport) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "Rate card %s -> %s\t\t\t\t\t\n", r.CurrentVersion, r.ProposedVersion)
+
+	section := func(title string, deltas []RevenueDelta) {
+		fmt.Fprintf(tw, "\n%s\tshipments\tcurrent\tproposed\tdelta\tdelta %%\t\n", strings.ToUpper(title))
+		for _, d := range deltas {
+			fmt.Fprintf(tw, "%s\t%d\t%.2f\t%.2f\t%+.2f\t%+.2f%%\t\n", d.Group, d.Shipments, d.Current, d.Proposed, d.Delta, d.DeltaPercent)
+		}
+	}
+	section("total", []RevenueDelta{r.Total})
+	section("zone", r.ByZone)
+	section("weight band", r.ByWeightBand)
+	section("segment", r.BySegment)
+
+	c := r.CustomerChanges
+	fmt.Fprintf(tw, "\nCUSTOMERS\t%d\tmin %+.2f%%\tmedian %+.2f%%\tmax %+.2f%%\t\t\n", c.Customers, c.Min, c.Median, c.Max)
+	for _, b := range c.Buckets {
+		fmt.Fprintf(tw, "%s\t%d\t\t\t\t\t\n", b.Label, b.Customers)
+	}
+	if r.Rejected > 0 {
+		fmt.Fprintf(tw, "\nrejected shipments\t%d\t\t\t\t\t\n", r.Rejected)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,103 @@
+// simulate_test.go
+package shipping
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+const sampleHistory = `customer_id,segment,weight,zone,insured
+acme,business,2,Domestic,false
+acme,business,20,Domestic,true
+bob,consumer,5,International,false
+bob,consumer,7,Domestic,false
+bob,consumer,0.5,Domestic,false
+carol,consumer,12,Express,false
+dave,consumer,60,Express,false
+`
+
+func TestSimulator_Run(t *testing.T) {
+	history, err := LoadHistory(strings.NewReader(sampleHistory))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Propose raising the heavy surcharge from 7.50 to 10.00
+	proposed := TieredRateCard
+	proposed.Version = "tiered-10"
+	proposed.HeavySurcharge = 10
+
+	report, err := Simulator{Current: TieredRateCard, Proposed: proposed}.Run(history)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// dave's 60kg parcel cannot be priced by either card
+	if report.Rejected != 1 {
+		t.Errorf("Expected 1 rejected shipment, got %d", report.Rejected)
+	}
+
+	// Two heavy parcels: 2.50 more each, plus 1.5% insurance on one of them
+	if math.Abs(report.Total.Delta-5.04) > 0.011 {
+		t.Errorf("Expected a total delta of 5.04, got %.2f", report.Total.Delta)
+	}
+
+	// Light parcels are unaffected
+	for _, d := range report.ByWeightBand {
+		if d.Group == "1-5kg" && d.Delta != 0 {
+			t.Errorf("Expected no change for 1-5kg, got %.2f", d.Delta)
+		}
+	}
+
+	// Bands are listed lightest first, not alphabetically
+	var bands []string
+	for _, d := range report.ByWeightBand {
+		bands = append(bands, d.Group)
+	}
+	if got := strings.Join(bands, " "); got != "0-1kg 1-5kg 5-10kg 10-20kg" {
+		t.Errorf("Expected bands in weight order, got %s", got)
+	}
+
+	// bob is unchanged, acme and carol pay more
+	dist := report.CustomerChanges
+	if dist.Customers != 3 || dist.Min != 0 || dist.Max <= 0 {
+		t.Errorf("Unexpected distribution: %+v", dist)
+	}
+	unchanged := 0
+	for _, b := range dist.Buckets {
+		if b.Label == "unchanged" {
+			unchanged = b.Customers
+		}
+	}
+	if unchanged != 1 {
+		t.Errorf("Expected 1 unchanged customer, got %d", unchanged)
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteText(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "tiered -> tiered-10") {
+		t.Errorf("Expected the card versions in the text report, got:\n%s", buf.String())
+	}
+}
+
+func TestLoadHistory_Invalid(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+	}{
+		{"Missing header", "acme,business,2,Domestic,false\n"},
+		{"Bad weight", "customer_id,segment,weight,zone,insured\nacme,business,two,Domestic,false\n"},
+		{"Bad insured flag", "customer_id,segment,weight,zone,insured\nacme,business,2,Domestic,maybe\n"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := LoadHistory(strings.NewReader(tc.input)); err == nil {
+				t.Error("Expected an error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+// snapshot.go
+package shipping
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"text/tabwriter"
+)
+
+// SnapshotFormat is the version of the golden file layout. Bump it when
+// the file format itself changes, not when prices change.
+const SnapshotFormat = 1
+
+// PriceFunc is the shape every calculator can be adapted to for snapshots.
+// shippingv2's CalculateShippingFee already has this signature.
+type PriceFunc func(weight float64, zone string, insured bool) (float64, error)
+
+// LegacyPriceFunc adapts CalculateShippingFee, which has no insurance option.
+func LegacyPriceFunc(weight float64, zone string, insured bool) (float64, error) {
+	return CalculateShippingFee(weight, zone)
+}
+
+// CalculatorPriceFunc adapts a Calculator, returning the quote total.
+func CalculatorPriceFunc(c *Calculator) PriceFunc {
+	return func(weight float64, zone string, insured bool) (float64, error) {
+		q, err := c.Quote(QuoteRequest{Weight: weight, Zone: zone, Insured: insured})
+		return q.Total, err
+	}
+}
+
+// SnapshotCase is one input in the snapshot grid.
+type SnapshotCase struct {
+	Weight  float64 `json:"weight"`
+	Zone    string  `json:"zone"`
+	Insured bool    `json:"insured"`
+}
+
+func (c SnapshotCase) String() string {
+	insured := "uninsured"
+	if c.Insured {
+		insured = "insured"
+	}
+	return fmt.Sprintf("%s %skg %s", c.Zone, strconv.FormatFloat(c.Weight, 'f', -1, 64), insured)
+}
+
+// SnapshotResult is what a calculator returned for one case.
+type SnapshotResult struct {
+	SnapshotCase
+	Fee   float64 `json:"fee"`
+	Error string  `json:"error,omitempty"`
+}
+
+// Snapshot is the full set of results for a calculator, as stored in a golden file.
+type Snapshot struct {
+	Format     int              `json:"format"`
+	Calculator string           `json:"calculator"`
+	Results    []SnapshotResult `json:"results"`
+}
+
+// DefaultSnapshotWeights sit on and either side of every weight boundary
+// the calculators have used.
+var DefaultSnapshotWeights = []float64{-1, 0, 0.1, 1, 5, 9.9, 10, 10.1, 25, 49.9, 50, 50.1}
+
+// DefaultSnapshotZones includes one invalid zone so error behaviour is captured too.
+var DefaultSnapshotZones = []string{"Domestic", "International", "Express", "Local"}
+
+// SnapshotGrid returns every combination of weight, zone and insured on/off.
+func SnapshotGrid(weights []float64, zones []string) []SnapshotCase {
+	var cases []SnapshotCase
+	for _, zone := range zones {
+		for _, weight := range weights {
+			for _, insured := range []bool{false, true} {
+				cases = append(cases, SnapshotCase{Weight: weight, Zone: zone, Insured: insured})
+			}
+		}
+	}
+	return cases
+}
+
+// TakeSnapshot runs price over every case.
+func TakeSnapshot(calculator string, price PriceFunc, cases []SnapshotCase) Snapshot {
+	s := Snapshot{Format: SnapshotFormat, Calculator: calculator}
+	for _, c := range cases {
+		fee, err := price(c.Weight, c.Zone, c.Insured)
+		result := SnapshotResult{SnapshotCase: c, Fee: math.Round(fee*10000) / 10000}
+		if err != nil {
+			result.Fee = 0
+			result.Error = err.Error()
+		}
+		s.Results = append(s.Results, result)
+	}
+	return s
+}
+
+// ReadSnapshot loads a golden file.
+func ReadSnapshot(path string) (Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return Snapshot{}, fmt.Errorf("parsing golden file %s: %w", path, err)
+	}
+	if s.Format != SnapshotFormat {
+		return Snapshot{}, fmt.Errorf("golden file %s has format %d, expected %d; regenerate it", path, s.Format, SnapshotFormat)
+	}
+	return s, nil
+}
+
+// WriteFile stores the snapshot as an indented JSON golden file.
+func (s Snapshot) WriteFile(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// SnapshotChange is a case whose result differs between two snapshots.
+// Golden or Current is nil when the case exists on only one side.
+type SnapshotChange struct {
+	Case    SnapshotCase
+	Golden  *SnapshotResult
+	Current *SnapshotResult
+}
+
+// SnapshotDiff lists every changed case, in the order of the current snapshot.
+type SnapshotDiff struct {
+	Changes []SnapshotChange
+}
+
+// Empty reports whether the snapshots matched.
+func (d SnapshotDiff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// DiffSnapshots compares a current snapshot against the golden one.
+func DiffSnapshots(golden, current Snapshot) SnapshotDiff {
+	byCase := make(map[SnapshotCase]SnapshotResult, len(golden.Results))
+	for _, r := range golden.Results {
+		byCase[r.SnapshotCase] = r
+	}
+
+	var diff SnapshotDiff
+	for _, cur := range current.Results {
+		cur := cur
+		old, ok := byCase[cur.SnapshotCase]
+		delete(byCase, cur.SnapshotCase)
+		switch {
+		case !ok:
+			diff.Changes = append(diff.Changes, SnapshotChange{Case: cur.SnapshotCase, Current: &cur})
+		case old.Error != cur.Error || math.Abs(old.Fee-cur.Fee) > 0.00005:
+			diff.Changes = append(diff.Changes, SnapshotChange{Case: cur.SnapshotCase, Golden: &old, Current: &cur})
+		}
+	}
+	for _, r := range golden.Results {
+		if _, removed := byCase[r.SnapshotCase]; removed {
+			r := r
+			diff.Changes = append(diff.Changes, SnapshotChange{Case: r.SnapshotCase, Golden: &r})
+		}
+	}
+	return diff
+}
+
+// WriteReport writes a human-readable table of the changes.
+func (d SnapshotDiff) WriteReport(w io.Writer) error {
+	if d.Empty() {
+		_, err := fmt.Fprintln(w, "no pricing changes")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "%d pricing change(s)\n", len(d.Changes))
+	fmt.Fprintln(tw, "CASE\tGOLDEN\tCURRENT\tDELTA")
+	for _, c := range d.Changes {
+		delta := ""
+		if c.Golden != nil && c.Current != nil && c.Golden.Error == "" && c.Current.Error == "" {
+			delta = fmt.Sprintf("%+.4f", c.Current.Fee-c.Golden.Fee)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", c.Case, describeResult(c.Golden), describeResult(c.Current), delta)
+	}
+	return tw.Flush()
+}
+
+func describeResult(r *SnapshotResult) string {
+	switch {
+	case r == nil:
+		return "(missing)"
+	case r.Error != "":
+		return "error: " + r.Error
+	default:
+		return strconv.FormatFloat(r.Fee, 'f', 4, 64)
+	}
+}
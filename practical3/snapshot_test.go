@@ -0,0 +1,79 @@
+// snapshot_test.go
+package shipping
+
+import (
+	"bytes"
+	"flag"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Run `go test -run Golden -update` to accept intentional pricing changes.
+var update = flag.Bool("update", false, "rewrite golden pricing files")
+
+func TestGolden_Calculator(t *testing.T) {
+	checkGolden(t, "calculator.golden.json", "Calculator (standard)", CalculatorPriceFunc(&Calculator{}))
+}
+
+// The original CalculateShippingFee has no insurance option, so its
+// insured and uninsured results must match.
+func TestGolden_Legacy(t *testing.T) {
+	checkGolden(t, "legacy.golden.json", "CalculateShippingFee", LegacyPriceFunc)
+}
+
+// checkGolden compares price's results over the default grid with the
+// golden file testdata/name, rewriting it first when -update is set.
+func checkGolden(t *testing.T, name, calculator string, price PriceFunc) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	current := TakeSnapshot(calculator, price, SnapshotGrid(DefaultSnapshotWeights, DefaultSnapshotZones))
+
+	if *update {
+		if err := current.WriteFile(path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	golden, err := ReadSnapshot(path)
+	if err != nil {
+		t.Fatalf("Could not read golden file (run with -update to create it): %v", err)
+	}
+	if diff := DiffSnapshots(golden, current); !diff.Empty() {
+		var report bytes.Buffer
+		diff.WriteReport(&report)
+		t.Errorf("Pricing differs from %s:\n%s", path, report.String())
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	cases := SnapshotGrid([]float64{5, 20}, []string{"Domestic"})
+	golden := TakeSnapshot("standard", StandardRateCard.Price, cases)
+
+	// Identical snapshots have no changes
+	if diff := DiffSnapshots(golden, golden); !diff.Empty() {
+		t.Errorf("Expected no changes, got %d", len(diff.Changes))
+	}
+
+	// Moving to the tiered card changes every case
+	current := TakeSnapshot("tiered", TieredRateCard.Price, cases)
+	diff := DiffSnapshots(golden, current)
+	if len(diff.Changes) != len(cases) {
+		t.Fatalf("Expected %d changes, got %d", len(cases), len(diff.Changes))
+	}
+
+	var report bytes.Buffer
+	if err := diff.WriteReport(&report); err != nil {
+		t.Fatal(err)
+	}
+	// Domestic 5kg uninsured: 10.00 on the standard card, 5.00 on the tiered one
+	if !strings.Contains(report.String(), "Domestic 5kg uninsured   10.0000  5.0000   -5.0000") {
+		t.Errorf("Unexpected report:\n%s", report.String())
+	}
+
+	// Cases that disappear from the grid are reported too
+	current.Results = current.Results[:1]
+	if diff := DiffSnapshots(golden, current); len(diff.Changes) != len(cases) || diff.Changes[1].Current != nil {
+		t.Errorf("Expected removed cases to be reported, got %+v", diff.Changes)
+	}
+}
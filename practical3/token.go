@@ -0,0 +1,163 @@
+// token.go
+package shipping
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Errors returned when a quote token is checked at checkout.
+var (
+	ErrQuoteTokenInvalid  = errors.New("quote token is invalid")
+	ErrQuoteTokenExpired  = errors.New("quote token has expired")
+	ErrQuoteTokenMismatch = errors.New("order does not match the quoted request or price")
+)
+
+// DefaultQuoteTTL is how long a locked price is honoured when no TTL is set.
+const DefaultQuoteTTL = 30 * time.Minute
+
+// QuoteLock is the payload carried inside a quote token: the request that
+// was priced, in its canonical form, the price the customer was shown and when the offer ends.
+type QuoteLock struct {
+	Request   json.RawMessage `json:"request"`
+	Total     float64         `json:"total"`
+	Currency  string          `json:"currency"`
+	IssuedAt  time.Time       `json:"issued_at"`
+	ExpiresAt time.Time       `json:"expires_at"`
+}
+
+// QuoteLocker issues and verifies HMAC-signed quote tokens so the price a
+// client sends back at checkout can be trusted.
+type QuoteLocker struct {
+	Key []byte
+	TTL time.Duration
+	// Now is used instead of time.Now when set, which keeps tests deterministic.
+	Now func() time.Time
+}
+
+// Issue signs a token locking q as the price for req.
+func (l *QuoteLocker) Issue(req QuoteRequest, q Quote) (string, error) {
+	if len(l.Key) == 0 {
+		return "", errors.New("quote locker has no signing key")
+	}
+
+	request, err := json.Marshal(canonicalRequest(req))
+	if err != nil {
+		return "", fmt.Errorf("encoding quote request: %w", err)
+	}
+
+	now := l.now()
+	lock := QuoteLock{
+		Request:   request,
+		Total:     q.Total,
+		Currency:  q.Currency,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(l.ttl()),
+	}
+	// Lock the amount the customer actually saw
+	if q.Presentment != nil {
+		lock.Total = q.Presentment.Total
+		lock.Currency = q.Presentment.Currency
+	}
+
+	payload, err := json.Marshal(lock)
+	if err != nil {
+		return "", fmt.Errorf("encoding quote lock: %w", err)
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + l.sign(encoded), nil
+}
+
+// Verify checks the token's signature and expiry and returns its payload.
+func (l *QuoteLocker) Verify(token string) (QuoteLock, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok || len(l.Key) == 0 {
+		return QuoteLock{}, ErrQuoteTokenInvalid
+	}
+	if !hmac.Equal([]byte(signature), []byte(l.sign(encoded))) {
+		return QuoteLock{}, ErrQuoteTokenInvalid
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return QuoteLock{}, ErrQuoteTokenInvalid
+	}
+	var lock QuoteLock
+	if err := json.Unmarshal(payload, &lock); err != nil {
+		return QuoteLock{}, ErrQuoteTokenInvalid
+	}
+
+	if !l.now().Before(lock.ExpiresAt) {
+		return lock, ErrQuoteTokenExpired
+	}
+	return lock, nil
+}
+
+// VerifyOrder verifies the token and confirms that the order being placed
+// is for the same request and price that were quoted.
+func (l *QuoteLocker) VerifyOrder(token string, req QuoteRequest, total float64, currency string) (QuoteLock, error) {
+	lock, err := l.Verify(token)
+	if err != nil {
+		return lock, err
+	}
+
+	var quoted QuoteRequest
+	if err := json.Unmarshal(lock.Request, &quoted); err != nil {
+		return lock, ErrQuoteTokenInvalid
+	}
+	if !reflect.DeepEqual(canonicalRequest(req), canonicalRequest(quoted)) ||
+		!strings.EqualFold(currency, lock.Currency) ||
+		math.Abs(total-lock.Total) > 0.000001 {
+		return lock, ErrQuoteTokenMismatch
+	}
+	return lock, nil
+}
+
+// canonicalRequest returns req with the fields that do not change the
+// price cleared and the rest in one spelling, so an order matches its
+// quote however the client formats the zone, currency or ship date.
+func canonicalRequest(req QuoteRequest) QuoteRequest {
+	req.ShipDate = req.ShipDate.UTC()
+	req.Zone = strings.ToLower(strings.Join(strings.Fields(req.Zone), " "))
+	req.Currency = strings.ToUpper(strings.TrimSpace(req.Currency))
+	req.Destination = strings.ToUpper(strings.TrimSpace(req.Destination))
+
+	items := req.Items
+	req.Items = nil
+	for _, item := range items {
+		item.Description = ""
+		item.Origin = strings.ToUpper(strings.TrimSpace(item.Origin))
+		req.Items = append(req.Items, item)
+	}
+	return req
+}
+
+// sign returns the base64url HMAC-SHA256 of the encoded payload.
+func (l *QuoteLocker) sign(encoded string) string {
+	mac := hmac.New(sha256.New, l.Key)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (l *QuoteLocker) now() time.Time {
+	if l.Now != nil {
+		return l.Now()
+	}
+	return time.Now()
+}
+
+func (l *QuoteLocker) ttl() time.Duration {
+	if l.TTL <= 0 {
+		return DefaultQuoteTTL
+	}
+	return l.TTL
+}
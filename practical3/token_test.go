@@ -0,0 +1,87 @@
+// token_test.go
+package shipping
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQuoteLocker(t *testing.T) {
+	now := time.Date(2025, time.December, 1, 12, 0, 0, 0, time.UTC)
+	locker := &QuoteLocker{Key: []byte("test-secret"), TTL: 10 * time.Minute, Now: func() time.Time { return now }}
+
+	req := QuoteRequest{Weight: 10, Zone: "Domestic"}
+	var c Calculator
+	q, err := c.Quote(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := locker.Issue(req, q)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	t.Run("Valid order", func(t *testing.T) {
+		lock, err := locker.VerifyOrder(token, req, 15, "USD")
+		if err != nil {
+			t.Fatalf("Expected the order to verify, got %v", err)
+		}
+		if !lock.ExpiresAt.Equal(now.Add(10 * time.Minute)) {
+			t.Errorf("Unexpected expiry %v", lock.ExpiresAt)
+		}
+	})
+
+	t.Run("Tampered payload", func(t *testing.T) {
+		payload, sig, _ := strings.Cut(token, ".")
+		tampered := payload[:len(payload)-2] + "AA." + sig
+		if _, err := locker.Verify(tampered); !errors.Is(err, ErrQuoteTokenInvalid) {
+			t.Errorf("Expected ErrQuoteTokenInvalid, got %v", err)
+		}
+	})
+
+	t.Run("Wrong key", func(t *testing.T) {
+		other := &QuoteLocker{Key: []byte("other-secret"), Now: locker.Now}
+		if _, err := other.Verify(token); !errors.Is(err, ErrQuoteTokenInvalid) {
+			t.Errorf("Expected ErrQuoteTokenInvalid, got %v", err)
+		}
+	})
+
+	t.Run("Different price", func(t *testing.T) {
+		if _, err := locker.VerifyOrder(token, req, 14, "USD"); !errors.Is(err, ErrQuoteTokenMismatch) {
+			t.Errorf("Expected ErrQuoteTokenMismatch, got %v", err)
+		}
+	})
+
+	t.Run("Same request spelled differently", func(t *testing.T) {
+		dated := QuoteRequest{Weight: 10, Zone: "Domestic", ShipDate: now}
+		token, err := locker.Issue(dated, q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		same := QuoteRequest{Weight: 10, Zone: " domestic ", ShipDate: now.In(time.FixedZone("CET", 3600))}
+		if _, err := locker.VerifyOrder(token, same, 15, "usd"); err != nil {
+			t.Errorf("Expected the order to verify, got %v", err)
+		}
+	})
+
+	t.Run("Different request", func(t *testing.T) {
+		heavier := QuoteRequest{Weight: 20, Zone: "Domestic"}
+		if _, err := locker.VerifyOrder(token, heavier, 15, "USD"); !errors.Is(err, ErrQuoteTokenMismatch) {
+			t.Errorf("Expected ErrQuoteTokenMismatch, got %v", err)
+		}
+		insured := QuoteRequest{Weight: 10, Zone: "Domestic", Insured: true}
+		if _, err := locker.VerifyOrder(token, insured, 15, "USD"); !errors.Is(err, ErrQuoteTokenMismatch) {
+			t.Errorf("Expected ErrQuoteTokenMismatch, got %v", err)
+		}
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		later := &QuoteLocker{Key: locker.Key, Now: func() time.Time { return now.Add(10 * time.Minute) }}
+		if _, err := later.Verify(token); !errors.Is(err, ErrQuoteTokenExpired) {
+			t.Errorf("Expected ErrQuoteTokenExpired, got %v", err)
+		}
+	})
+}
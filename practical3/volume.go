@@ -0,0 +1,136 @@
+// volume.go
+package shipping
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ShipmentCounter reports how many shipments a customer has sent in a period.
+type ShipmentCounter interface {
+	Count(ctx context.Context, customerID string, period BillingPeriod) (int, error)
+}
+
+// VolumeTier discounts the shipping fee by Discount (0.10 = 10%) once a
+// customer has already sent MinShipments in the current period.
+type VolumeTier struct {
+	MinShipments int     `json:"min_shipments"`
+	Discount     float64 `json:"discount"`
+}
+
+// VolumePricing applies volume tiers using a ShipmentCounter.
+type VolumePricing struct {
+	Tiers   []VolumeTier
+	Counter ShipmentCounter
+	// Period maps a ship date to its counting period. Defaults to the calendar month.
+	Period func(time.Time) BillingPeriod
+}
+
+// Tier returns the best tier a customer with count prior shipments reaches.
+func (v *VolumePricing) Tier(count int) (VolumeTier, bool) {
+	tiers := append([]VolumeTier(nil), v.Tiers...)
+	sort.Slice(tiers, func(i, j int) bool { return tiers[i].MinShipments > tiers[j].MinShipments })
+	for _, t := range tiers {
+		if count >= t.MinShipments {
+			return t, true
+		}
+	}
+	return VolumeTier{}, false
+}
+
+// Discount returns the volume discount line for a parcel costing fee and
+// shipped on shipDate, which picks the counting period and must be set.
+// Requests without a customer ID are never discounted.
+func (v *VolumePricing) Discount(ctx context.Context, customerID string, shipDate time.Time, fee float64) (LineItem, bool, error) {
+	if customerID == "" || v.Counter == nil {
+		return LineItem{}, false, nil
+	}
+	if shipDate.IsZero() {
+		return LineItem{}, false, errors.New("volume discount needs a ship date")
+	}
+
+	period := v.period(shipDate)
+
+	count, err := v.Counter.Count(ctx, customerID, period)
+	if err != nil {
+		return LineItem{}, false, fmt.Errorf("counting shipments for %s: %w", customerID, err)
+	}
+
+	tier, ok := v.Tier(count)
+	if !ok || tier.Discount == 0 {
+		return LineItem{}, false, nil
+	}
+	return newLine("volume_discount", -fee*tier.Discount, strconv.Itoa(tier.MinShipments)), true, nil
+}
+
+func (v *VolumePricing) period(shipDate time.Time) BillingPeriod {
+	if v.Period != nil {
+		return v.Period(shipDate)
+	}
+	utc := shipDate.UTC()
+	return MonthlyPeriod(utc.Year(), utc.Month())
+}
+
+// MemoryCounter counts shipments recorded in memory. It is safe for concurrent use.
+type MemoryCounter struct {
+	mu        sync.Mutex
+	shipments map[string][]time.Time
+}
+
+// NewMemoryCounter returns an empty counter.
+func NewMemoryCounter() *MemoryCounter {
+	return &MemoryCounter{shipments: make(map[string][]time.Time)}
+}
+
+// Record notes that customerID sent a shipment at the given time.
+func (m *MemoryCounter) Record(customerID string, at time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.shipments[customerID] = append(m.shipments[customerID], at)
+}
+
+// Count implements ShipmentCounter.
+func (m *MemoryCounter) Count(ctx context.Context, customerID string, period BillingPeriod) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	count := 0
+	for _, at := range m.shipments[customerID] {
+		if period.Contains(at) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// DefaultCountQuery counts rows in a shipments table using PostgreSQL placeholders.
+const DefaultCountQuery = "SELECT COUNT(*) FROM shipments WHERE customer_id = $1 AND shipped_at >= $2 AND shipped_at < $3"
+
+// SQLCounter counts shipments stored in a SQL database.
+type SQLCounter struct {
+	DB *sql.DB
+	// Query takes the customer ID, period start and period end, in that
+	// order. Defaults to DefaultCountQuery.
+	Query string
+}
+
+// Count implements ShipmentCounter.
+func (s *SQLCounter) Count(ctx context.Context, customerID string, period BillingPeriod) (int, error) {
+	query := s.Query
+	if query == "" {
+		query = DefaultCountQuery
+	}
+
+	var count int
+	err := s.DB.QueryRowContext(ctx, query, customerID, period.Start, period.End).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count shipments: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,143 @@
+// volume_test.go
+package shipping
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestVolumePricing_Quote(t *testing.T) {
+	counter := NewMemoryCounter()
+	for i := 0; i < 12; i++ {
+		counter.Record("acme", time.Date(2025, time.November, 1+i, 0, 0, 0, 0, time.UTC))
+	}
+	// Last month's shipments do not count
+	counter.Record("bob", time.Date(2025, time.October, 30, 0, 0, 0, 0, time.UTC))
+
+	c := Calculator{Volume: &VolumePricing{
+		Tiers:   []VolumeTier{{MinShipments: 5, Discount: 0.05}, {MinShipments: 10, Discount: 0.10}},
+		Counter: counter,
+	}}
+	shipDate := time.Date(2025, time.November, 20, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		customer string
+		expected float64
+	}{
+		{"Top tier", "acme", 13.50}, // 15 - 10%
+		{"No shipments this month", "bob", 15},
+		{"Anonymous sender", "", 15},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := c.Quote(QuoteRequest{Weight: 10, Zone: "Domestic", ShipDate: shipDate, CustomerID: tc.customer})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if q.Total != tc.expected {
+				t.Errorf("Expected total %.2f, got %.2f", tc.expected, q.Total)
+			}
+		})
+	}
+}
+
+func TestVolumePricing_UndatedUsesCalculatorClock(t *testing.T) {
+	counter := NewMemoryCounter()
+	for i := 0; i < 5; i++ {
+		counter.Record("acme", time.Date(2025, time.November, 1+i, 0, 0, 0, 0, time.UTC))
+	}
+	volume := &VolumePricing{Tiers: []VolumeTier{{MinShipments: 5, Discount: 0.10}}, Counter: counter}
+
+	// Undated requests count shipments in the calculator's current month
+	c := Calculator{Volume: volume, Now: func() time.Time { return time.Date(2025, time.November, 20, 0, 0, 0, 0, time.UTC) }}
+	q, err := c.Quote(QuoteRequest{Weight: 10, Zone: "Domestic", CustomerID: "acme"})
+	if err != nil || q.Total != 13.50 {
+		t.Errorf("Expected a 13.50 discounted quote, got %.2f, %v", q.Total, err)
+	}
+
+	if _, _, err := volume.Discount(context.Background(), "acme", time.Time{}, 15); err == nil {
+		t.Error("Expected an error for a discount without a ship date")
+	}
+}
+
+func TestVolumePricing_CounterError(t *testing.T) {
+	c := Calculator{Volume: &VolumePricing{
+		Tiers:   []VolumeTier{{MinShipments: 1, Discount: 0.05}},
+		Counter: &SQLCounter{DB: sql.OpenDB(countConnector{err: errors.New("connection refused")})},
+	}}
+	if _, err := c.Quote(QuoteRequest{Weight: 1, Zone: "Domestic", CustomerID: "acme"}); err == nil {
+		t.Error("Expected the counter error to be returned")
+	}
+}
+
+func TestSQLCounter(t *testing.T) {
+	conn := countConnector{count: 7}
+	counter := &SQLCounter{DB: sql.OpenDB(&conn)}
+
+	period := MonthlyPeriod(2025, time.November)
+	count, err := counter.Count(context.Background(), "acme", period)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 7 {
+		t.Errorf("Expected 7, got %d", count)
+	}
+}
+
+// countConnector is a minimal database/sql driver that answers every query
+// with a single count, or fails with err.
+type countConnector struct {
+	count int64
+	err   error
+}
+
+func (c countConnector) Connect(context.Context) (driver.Conn, error) { return countConn(c), nil }
+func (c countConnector) Driver() driver.Driver                        { return nil }
+
+type countConn countConnector
+
+func (c countConn) Prepare(query string) (driver.Stmt, error) { return countStmt(c), nil }
+func (c countConn) Close() error                              { return nil }
+func (c countConn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+
+type countStmt countConnector
+
+func (s countStmt) Close() error  { return nil }
+func (s countStmt) NumInput() int { return 3 }
+func (s countStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+
+func (s countStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if args[0] != "acme" {
+		return nil, errors.New("unexpected customer argument")
+	}
+	return &countRows{count: s.count}, nil
+}
+
+type countRows struct {
+	count int64
+	done  bool
+}
+
+func (r *countRows) Columns() []string { return []string{"count"} }
+func (r *countRows) Close() error      { return nil }
+
+func (r *countRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.count
+	return nil
+}